@@ -0,0 +1,5 @@
+// Package compat holds the tests that run the same calls through every
+// adapter and compare what they write. It is a module of its own, never
+// published, so the root module does not depend on the third-party
+// backends.
+package compat
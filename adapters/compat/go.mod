@@ -0,0 +1,11 @@
+module github.com/BrunoTulio/logr/adapters/compat
+
+go 1.22.10
+
+require (
+	github.com/BrunoTulio/logr v0.1.0
+	github.com/BrunoTulio/logr/adapters/logrus.v1 v0.1.0
+	github.com/BrunoTulio/logr/adapters/slog.v1 v0.1.0
+	github.com/BrunoTulio/logr/adapters/zap.v1 v0.1.0
+	github.com/BrunoTulio/logr/adapters/zerolog.v1 v0.1.0
+)
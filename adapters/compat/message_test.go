@@ -0,0 +1,137 @@
+package compat_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/adapters/logrus.v1"
+	"github.com/BrunoTulio/logr/adapters/native"
+	"github.com/BrunoTulio/logr/adapters/slog.v1"
+	"github.com/BrunoTulio/logr/adapters/zap.v1"
+	"github.com/BrunoTulio/logr/adapters/zerolog.v1"
+)
+
+// adapters creates one logger per adapter writing JSON records to a file in
+// dir, so their output can be compared.
+var adapters = map[string]func(dir string) logr.Logger{
+	"logrus": func(dir string) logr.Logger {
+		return logrus.New(logrus.WithFile(true, dir, "app.log"), logrus.WithFileFormatter("JSON"), logrus.WithFileLevel("DEBUG"))
+	},
+	"native": func(dir string) logr.Logger {
+		return native.New(native.WithFile(true, dir, "app.log"), native.WithFileFormatter("JSON"), native.WithFileLevel("DEBUG"))
+	},
+	"slog": func(dir string) logr.Logger {
+		return slog.New(slog.WithFile(true, dir, "app.log"), slog.WithFileFormatter("JSON"), slog.WithFileLevel("DEBUG"))
+	},
+	"zap": func(dir string) logr.Logger {
+		return zap.New(zap.WithFile(true, dir, "app.log"), zap.WithFileFormatter("JSON"), zap.WithFileLevel("DEBUG"))
+	},
+	"zerolog": func(dir string) logr.Logger {
+		return zerolog.New(zerolog.WithFile(true, dir, "app.log"), zerolog.WithFormatter("JSON"), zerolog.WithLevel("DEBUG"))
+	},
+}
+
+func TestMessages(t *testing.T) {
+	ctx := context.Background()
+	want := []string{
+		"info user 42",
+		"warn user 42",
+		"error user 42",
+		"debug user 42",
+		"info ctx 42",
+		"warn ctx 42",
+		"error ctx 42",
+		"debug ctx 42",
+		"%s literal",
+		"100%",
+	}
+
+	for name, newLogger := range adapters {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			logger := newLogger(dir)
+
+			logger.Infof("info user %d", 42)
+			logger.Warnf("warn user %d", 42)
+			logger.Errorf("error user %d", 42)
+			logger.Debugf("debug user %d", 42)
+			logger.InfofContext(ctx, "info ctx %d", 42)
+			logger.WarnfContext(ctx, "warn ctx %d", 42)
+			logger.ErrorfContext(ctx, "error ctx %d", 42)
+			logger.DebugfContext(ctx, "debug ctx %d", 42)
+			logger.Info("%s literal")
+			logger.Infof("100%%")
+
+			got := readMessages(t, filepath.Join(dir, "app.log"))
+			if len(got) != len(want) {
+				t.Fatalf("got %d records %q, want %d", len(got), got, len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("record %d: message = %q, want %q", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMessagesDisabledLevel(t *testing.T) {
+	for name, newLogger := range adapters {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			logger := newLogger(dir)
+			logger.SetLevel(logr.LevelInfo)
+
+			logger.Debugf("%v", formatPanics{})
+			logger.DebugfContext(context.Background(), "%v", formatPanics{})
+
+			if got := readMessages(t, filepath.Join(dir, "app.log")); len(got) != 0 {
+				t.Fatalf("got records %q, want none", got)
+			}
+		})
+	}
+}
+
+// formatPanics fails the test if a disabled record is formatted.
+type formatPanics struct{}
+
+func (formatPanics) String() string {
+	panic("disabled record was formatted")
+}
+
+func readMessages(t *testing.T, path string) []string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var messages []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("decode %q: %v", scanner.Text(), err)
+		}
+		// zerolog usa "message"; os demais, "msg"
+		message, ok := record["msg"].(string)
+		if !ok {
+			message, _ = record["message"].(string)
+		}
+		messages = append(messages, message)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return messages
+}
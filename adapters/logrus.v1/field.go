@@ -1,20 +1,78 @@
 package logrus
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
 	"github.com/sirupsen/logrus"
 
 	"github.com/BrunoTulio/logr"
 )
 
+// buildFields redacts fields and maps them to logrus fields. Nested fields
+// are redacted along with their group.
 func buildFields(fields logr.Fields) logrus.Fields {
+	return buildGroup(logr.RedactFields(fields))
+}
+
+func buildGroup(fields logr.Fields) logrus.Fields {
 	result := make(logrus.Fields, len(fields))
 	for _, f := range fields {
-		if f.Type == logr.GroupType {
-			groupFields := f.Value.([]logr.Field)
-			result[f.Key] = buildFields(groupFields)
-		} else {
-			result[f.Key] = f.Value
+		if f.Type == logr.ErrorType {
+			addErrorValues(result, f)
+			continue
 		}
+		result[f.Key] = buildValue(f)
 	}
 	return result
 }
+
+// buildValue returns the value logrus should format for f. Formatters handle
+// numbers, strings, times and slices by their dynamic type, so those (and
+// values that do not match their FieldType) are passed through as is.
+func buildValue(f logr.Field) any {
+	switch f.Type {
+	case logr.BytesType:
+		if v, ok := f.Value.([]byte); ok {
+			return base64.StdEncoding.EncodeToString(v)
+		}
+	case logr.HexType:
+		if v, ok := f.Value.([]byte); ok {
+			return hex.EncodeToString(v)
+		}
+	case logr.StringerType:
+		if v, ok := f.Value.(fmt.Stringer); ok {
+			return fmt.Sprint(v)
+		}
+	case logr.GroupType:
+		if groupFields, ok := f.Value.([]logr.Field); ok {
+			return buildGroup(groupFields)
+		}
+	case logr.ObjectType:
+		if v, ok := f.Value.(logr.ObjectMarshaler); ok {
+			return marshalFields(v)
+		}
+	case logr.SecretType:
+		return logr.RedactedValue
+	case logr.StringType, logr.BoolType, logr.IntType, logr.Int64Type, logr.Int32Type,
+		logr.UintType, logr.Uint32Type, logr.Uint64Type, logr.Float32Type, logr.Float64Type,
+		logr.TimeType, logr.DurationType, logr.StringsType, logr.IntsType, logr.AnyType, logr.ErrorType,
+		logr.LazyType, logr.LazyGroupType:
+	}
+	return f.Value
+}
+
+// addErrorValues adds an ErrorType field as plain values, since an error
+// nested in a group would otherwise be encoded as an empty JSON object.
+func addErrorValues(result logrus.Fields, f logr.Field) {
+	err, _ := f.Value.(error)
+	if err == nil {
+		return
+	}
+	result[f.Key] = err.Error()
+	result[f.Key+logr.ErrorChainSuffix] = logr.ErrorChain(err)
+	if verbose := logr.ErrorVerbose(err); verbose != "" {
+		result[f.Key+logr.ErrorVerboseSuffix] = verbose
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/BrunoTulio/logr/adapters/logrus.v1
+
+go 1.22.10
+
+require (
+	github.com/BrunoTulio/logr v0.1.0
+	github.com/sirupsen/logrus v1.9.3
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
+)
+
+require golang.org/x/sys v0.12.0 // indirect
@@ -2,19 +2,69 @@ package logrus
 
 import (
 	"github.com/sirupsen/logrus"
+
+	"github.com/BrunoTulio/logr"
+)
+
+const (
+	sinkConsole = "console"
+	sinkFile    = "file"
 )
 
-func buildLevel(level string) logrus.Level {
+// buildLevel returns the level named level, or INFO when it is empty or
+// invalid.
+func buildLevel(level string) logr.Level {
+	if parsed, err := logr.ParseLevel(level); err == nil {
+		return parsed
+	}
+	return logr.LevelInfo
+}
+
+// buildFormat returns the format named format, or TEXT when it is empty or
+// invalid.
+func buildFormat(format string) logr.Format {
+	if parsed, err := logr.ParseFormat(format); err == nil {
+		return parsed
+	}
+	return logr.FormatText
+}
+
+func toLogrusLevel(level logr.Level) logrus.Level {
 	switch level {
-	case "DEBUG":
+	case logr.LevelTrace:
+		return logrus.TraceLevel
+	case logr.LevelDebug:
 		return logrus.DebugLevel
-	case "INFO":
+	case logr.LevelInfo:
 		return logrus.InfoLevel
-	case "WARN":
+	case logr.LevelWarn:
 		return logrus.WarnLevel
-	case "ERROR":
+	case logr.LevelError:
 		return logrus.ErrorLevel
+	case logr.LevelPanic:
+		return logrus.PanicLevel
+	case logr.LevelFatal:
+		return logrus.FatalLevel
 	default:
 		return logrus.InfoLevel
 	}
 }
+
+func fromLogrusLevel(level logrus.Level) logr.Level {
+	switch level {
+	case logrus.TraceLevel:
+		return logr.LevelTrace
+	case logrus.DebugLevel:
+		return logr.LevelDebug
+	case logrus.WarnLevel:
+		return logr.LevelWarn
+	case logrus.ErrorLevel:
+		return logr.LevelError
+	case logrus.PanicLevel:
+		return logr.LevelPanic
+	case logrus.FatalLevel:
+		return logr.LevelFatal
+	default:
+		return logr.LevelInfo
+	}
+}
@@ -2,84 +2,248 @@ package logrus
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
-	"os"
 	"path"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
 )
 
 type ctxKey struct{}
 
-var _ logr.Logger = (*logger)(nil)
+var (
+	_ logr.Logger      = (*logger)(nil)
+	_ logr.DropCounter = (*logger)(nil)
+)
 
-type logger struct {
-	logger *logrus.Entry
-	writer io.Writer
-	fields logr.Fields
-	option *Option
-}
+type (
+	logger struct {
+		// root is shared by every logger derived from the same New and is
+		// replaced by Reload.
+		root   *atomic.Pointer[backend]
+		bound  atomic.Pointer[bound]
+		fields logr.Fields
+		lazy   logr.Fields
+	}
+
+	// backend is what Reload rebuilds from a new config.
+	backend struct {
+		logger  *logrus.Entry
+		writer  io.Writer
+		writers []io.Writer
+		byName  map[string]config.Sink
+		option  *Option
+		// sampler is nil unless WithSampling was given.
+		sampler *logr.Sampler
+	}
+
+	// bound is the backend logger extended with the eager fields of one
+	// logger.
+	bound struct {
+		backend *backend
+		logger  *logrus.Entry
+	}
+)
 
 // Info implements logr.Logger.
 func (l *logger) Info(message string) {
-	l.logger.Info(message)
+	l.at(logr.LevelInfo).Info(message)
 }
 
 // Infof implements logr.Logger.
 func (l *logger) Infof(format string, args ...interface{}) {
-	l.logger.Infof(format, args...)
+	if l.Enabled(logr.LevelInfo) {
+		l.at(logr.LevelInfo).Infof(format, args...)
+	}
+}
+
+// InfoContext implements logr.Logger.
+func (l *logger) InfoContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelInfo) {
+		l.at(logr.LevelInfo).WithContext(ctx).WithFields(buildFields(logr.Resolve(l.contextFields(ctx)))).Info(message)
+	}
+}
+
+// InfofContext implements logr.Logger.
+func (l *logger) InfofContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelInfo) {
+		l.at(logr.LevelInfo).WithContext(ctx).WithFields(buildFields(logr.Resolve(l.contextFields(ctx)))).Infof(format, args...)
+	}
+}
+
+// Infow implements logr.Logger.
+func (l *logger) Infow(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelInfo) {
+		l.at(logr.LevelInfo).WithFields(buildFields(logr.Resolve(fields))).Info(message)
+	}
 }
 
 // Warn implements logr.Logger.
 func (l *logger) Warn(message string) {
-	l.logger.Warn(message)
+	l.at(logr.LevelWarn).Warn(message)
 }
 
 // Warnf implements logr.Logger.
 func (l *logger) Warnf(format string, args ...interface{}) {
-	l.logger.Warnf(format, args...)
+	if l.Enabled(logr.LevelWarn) {
+		l.at(logr.LevelWarn).Warnf(format, args...)
+	}
+}
+
+// WarnContext implements logr.Logger.
+func (l *logger) WarnContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelWarn) {
+		l.at(logr.LevelWarn).WithContext(ctx).WithFields(buildFields(logr.Resolve(l.contextFields(ctx)))).Warn(message)
+	}
+}
+
+// WarnfContext implements logr.Logger.
+func (l *logger) WarnfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelWarn) {
+		l.at(logr.LevelWarn).WithContext(ctx).WithFields(buildFields(logr.Resolve(l.contextFields(ctx)))).Warnf(format, args...)
+	}
+}
+
+// Warnw implements logr.Logger.
+func (l *logger) Warnw(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelWarn) {
+		l.at(logr.LevelWarn).WithFields(buildFields(logr.Resolve(fields))).Warn(message)
+	}
 }
 
 // Debug implements logr.Logger.
 func (l *logger) Debug(message string) {
-	l.logger.Debug(message)
+	l.at(logr.LevelDebug).Debug(message)
 }
 
 // Debugf implements logr.Logger.
 func (l *logger) Debugf(format string, args ...interface{}) {
-	l.logger.Debugf(format, args...)
+	if l.Enabled(logr.LevelDebug) {
+		l.at(logr.LevelDebug).Debugf(format, args...)
+	}
+}
+
+// DebugContext implements logr.Logger.
+func (l *logger) DebugContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelDebug) {
+		l.at(logr.LevelDebug).WithContext(ctx).WithFields(buildFields(logr.Resolve(l.contextFields(ctx)))).Debug(message)
+	}
+}
+
+// DebugfContext implements logr.Logger.
+func (l *logger) DebugfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelDebug) {
+		l.at(logr.LevelDebug).WithContext(ctx).WithFields(buildFields(logr.Resolve(l.contextFields(ctx)))).Debugf(format, args...)
+	}
+}
+
+// Debugw implements logr.Logger.
+func (l *logger) Debugw(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelDebug) {
+		l.at(logr.LevelDebug).WithFields(buildFields(logr.Resolve(fields))).Debug(message)
+	}
 }
 
 // Error implements logr.Logger.
 func (l *logger) Error(message string) {
-	l.logger.Error(message)
+	l.at(logr.LevelError).Error(message)
 }
 
 // Errorf implements logr.Logger.
 func (l *logger) Errorf(format string, args ...interface{}) {
-	l.logger.Errorf(format, args...)
+	if l.Enabled(logr.LevelError) {
+		l.at(logr.LevelError).Errorf(format, args...)
+	}
 }
 
-// Fatal implements logr.Logger.
+// ErrorContext implements logr.Logger.
+func (l *logger) ErrorContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelError) {
+		l.at(logr.LevelError).WithContext(ctx).WithFields(buildFields(logr.Resolve(l.contextFields(ctx)))).Error(message)
+	}
+}
+
+// ErrorfContext implements logr.Logger.
+func (l *logger) ErrorfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelError) {
+		l.at(logr.LevelError).WithContext(ctx).WithFields(buildFields(logr.Resolve(l.contextFields(ctx)))).Errorf(format, args...)
+	}
+}
+
+// Errorw implements logr.Logger.
+func (l *logger) Errorw(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelError) {
+		l.at(logr.LevelError).WithFields(buildFields(logr.Resolve(fields))).Error(message)
+	}
+}
+
+// Fatal implements logr.Logger. Entry.Log is used instead of Entry.Fatal,
+// which would exit through logrus without the shutdown hooks.
 func (l *logger) Fatal(message string) {
-	l.logger.Fatal(message)
+	l.at(logr.LevelFatal).Log(logrus.FatalLevel, message)
+	l.root.Load().fatal()
 }
 
 // Fatalf implements logr.Logger.
 func (l *logger) Fatalf(format string, args ...interface{}) {
-	l.logger.Fatalf(format, args...)
+	l.at(logr.LevelFatal).Logf(logrus.FatalLevel, format, args...)
+	l.root.Load().fatal()
+}
+
+// Panic implements logr.Logger.
+func (l *logger) Panic(message string) {
+	message = logr.MaskMessage(message)
+	defer panicWith(message)
+	l.at(logr.LevelPanic).Panic(message)
+}
+
+// Panicf implements logr.Logger.
+func (l *logger) Panicf(format string, args ...interface{}) {
+	message := logr.MaskMessage(fmt.Sprintf(format, args...))
+	defer panicWith(message)
+	l.at(logr.LevelPanic).Panic(message)
+}
+
+// Trace implements logr.Logger.
+func (l *logger) Trace(message string) {
+	l.at(logr.LevelTrace).Trace(message)
+}
+
+// Tracef implements logr.Logger.
+func (l *logger) Tracef(format string, args ...interface{}) {
+	if l.Enabled(logr.LevelTrace) {
+		l.at(logr.LevelTrace).Tracef(format, args...)
+	}
+}
+
+// Log implements logr.Logger.
+func (l *logger) Log(level logr.Level, message string, fields ...logr.Field) {
+	// o logrus entra em pânico no nível Panic mesmo quando nenhum sink o aceita
+	if level == logr.LevelPanic {
+		message = logr.MaskMessage(message)
+		defer panicWith(message)
+	}
+	if l.Enabled(level) || level >= logr.LevelPanic {
+		l.at(level).WithFields(buildFields(logr.Resolve(fields))).Log(toLogrusLevel(level), message)
+	}
+	// Entry.Log não encerra o processo como Entry.Fatal
+	if level == logr.LevelFatal {
+		l.root.Load().fatal()
+	}
 }
 
 // FromContext implements logr.Logger.
 func (l *logger) FromContext(ctx context.Context) logr.Logger {
-	fields, ok := ctx.Value(ctxKey{}).(logr.Fields)
-	if !ok {
-		fields = logr.Fields{}
-	}
-	return l.WithFields(fields...)
+	return l.withContext(ctx)
 }
 
 // GetFields implements logr.Logger.
@@ -87,9 +251,47 @@ func (l *logger) GetFields() logr.Fields {
 	return l.fields
 }
 
+// Level implements logr.Logger.
+func (l *logger) Level() logr.Level {
+	return config.LowestLevel(l.root.Load().byName)
+}
+
+// SetLevel implements logr.Logger.
+func (l *logger) SetLevel(level logr.Level) {
+	config.SetLevel(l.root.Load().byName, level)
+}
+
+// Enabled implements logr.Logger.
+func (l *logger) Enabled(level logr.Level) bool {
+	return config.Enabled(l.root.Load().byName, level)
+}
+
+// SinkLevels returns the level of every enabled sink, keyed by sink name
+// ("console", "file"). Changing a returned level affects every logger derived
+// from l.
+func (l *logger) SinkLevels() map[string]*logr.AtomicLevel {
+	return config.SinkLevels(l.root.Load().byName)
+}
+
+// AsyncDropped implements logr.DropCounter. Only the sinks written through
+// WithAsync are included, and their counts survive Reload.
+func (l *logger) AsyncDropped() map[string]uint64 {
+	return config.Dropped(l.root.Load().byName)
+}
+
 // Output implements logr.Logger.
 func (l *logger) Output() io.Writer {
-	return l.writer
+	return l.root.Load().writer
+}
+
+// Sync implements logr.Logger.
+func (l *logger) Sync() error {
+	return config.Sync(l.root.Load().writers)
+}
+
+// Close implements logr.Logger.
+func (l *logger) Close() error {
+	return l.root.Load().Close()
 }
 
 // ToContext implements logr.Logger.
@@ -104,15 +306,58 @@ func (l *logger) WithField(field logr.Field) logr.Logger {
 
 // WithFields implements logr.Logger.
 func (l *logger) WithFields(fields ...logr.Field) logr.Logger {
-	//nolint:gocritic // appendAssign: necessário criar nova slice para manter imutabilidade
-	newFields := append(l.fields, fields...)
-	args := buildFields(newFields)
-	return &logger{
-		option: l.option,
-		logger: l.logger.WithFields(args),
-		writer: l.writer,
-		fields: newFields,
+	eager, lazy := logr.SplitLazy(fields)
+
+	parent := l.bind()
+	derived := &logger{
+		root:   l.root,
+		fields: slices.Concat(l.fields, fields),
+		lazy:   slices.Concat(l.lazy, lazy),
+	}
+	derived.bound.Store(&bound{backend: parent.backend, logger: parent.logger.WithFields(buildFields(eager))})
+	return derived
+}
+
+// bind returns the backend logger with the eager fields of l, rebuilding it
+// when a Reload replaced the backend it was bound to.
+func (l *logger) bind() *bound {
+	current := l.root.Load()
+	if b := l.bound.Load(); b != nil && b.backend == current {
+		return b
+	}
+	eager, _ := logr.SplitLazy(l.fields)
+	b := &bound{backend: current, logger: current.logger.WithFields(buildFields(eager))}
+	l.bound.Store(b)
+	return b
+}
+
+// at returns the backend logger for a record at level, extended with the
+// lazy fields only when that record is going to be written.
+func (l *logger) at(level logr.Level) *logrus.Entry {
+	current := l.bind().logger
+	if len(l.lazy) == 0 || !l.Enabled(level) {
+		return current
+	}
+	return current.WithFields(buildFields(logr.Resolve(l.lazy)))
+}
+
+// withContext returns l extended with the fields stored in ctx by ToContext
+// and those returned by the configured context extractors.
+func (l *logger) withContext(ctx context.Context) *logger {
+	fields := l.contextFields(ctx)
+	if len(fields) == 0 {
+		return l
 	}
+	return l.WithFields(fields...).(*logger)
+}
+
+// contextFields returns the fields stored in ctx by ToContext followed by
+// those returned by the context extractors, without the keys l already
+// carries (see logr.ContextFields). The *Context methods log them with the
+// record, without deriving a logger.
+func (l *logger) contextFields(ctx context.Context) logr.Fields {
+	stored, _ := ctx.Value(ctxKey{}).(logr.Fields)
+	return logr.ContextFields(ctx, stored, l.fields, l.root.Load().option.ContextExtractors)
 }
 
 func New(fns ...FnOption) logr.Logger {
@@ -120,6 +365,16 @@ func New(fns ...FnOption) logr.Logger {
 	return NewWithOption(option)
 }
 
+// NewE is like New, but reports invalid levels and formats instead of
+// falling back to INFO and TEXT.
+func NewE(fns ...FnOption) (logr.Logger, error) {
+	option := options(fns)
+	if err := option.validate(); err != nil {
+		return nil, err
+	}
+	return NewWithOption(option), nil
+}
+
 func NewWithOption(o *Option) logr.Logger {
 	l := newLogger(o)
 	logr.Set(l)
@@ -127,57 +382,137 @@ func NewWithOption(o *Option) logr.Logger {
 }
 
 func newLogger(o *Option, fields ...logr.Field) *logger {
+	l := &logger{
+		root:   &atomic.Pointer[backend]{},
+		fields: fields,
+	}
+	l.root.Store(newBackend(o))
+	return l
+}
+
+func newBackend(o *Option) *backend {
 	logrusLogger := logrus.New()
-	logrusLogger.SetLevel(logrus.InfoLevel)
+	// Cada sink é um WriterHook com o próprio nível, então o logger deixa
+	// passar tudo e não escreve nada por conta própria.
+	logrusLogger.SetLevel(logrus.TraceLevel)
+	logrusLogger.SetOutput(io.Discard)
+	logrusLogger.SetFormatter(discardFormatter{})
 
 	if o.AddSource {
 		logrusLogger.SetReportCaller(true)
 	}
 
+	var hooks []logrus.Hook
 	var writers []io.Writer
+	byName := make(map[string]config.Sink)
 
 	if o.Console.Enabled {
-		logrusLogger.SetLevel(buildLevel(o.Console.Level))
-		logrusLogger.SetOutput(os.Stdout)
-		logrusLogger.SetFormatter(buildFormatter(o.Console.Formatter))
-		writers = append(writers, os.Stdout)
+		consoleWriter := o.async(o.consoleWriter())
+		console := config.NewSink(consoleWriter, buildLevel(o.Console.Level))
+		hooks = append(hooks, &WriterHook{
+			Writer:    console.Writer,
+			Formatter: buildFormatter(o.Console.Formatter),
+			Level:     console.Level,
+		})
+		writers = append(writers, consoleWriter)
+		byName[sinkConsole] = console
 	}
 
 	if o.File.Enabled {
-		fileWriter := &lumberjack.Logger{
+		fileWriter := o.async(&lumberjack.Logger{
 			Filename: path.Join(o.File.Path, o.File.Name),
 			MaxSize:  o.File.MaxSize,
 			MaxAge:   o.File.MaxAge,
 			Compress: o.File.Compress,
-		}
-		logrusLogger.AddHook(&WriterHook{
-			Writer:    fileWriter,
+		})
+		file := config.NewSink(fileWriter, buildLevel(o.File.Level))
+		hooks = append(hooks, &WriterHook{
+			Writer:    file.Writer,
 			Formatter: buildFormatter(o.File.Formatter),
-			Level:     buildLevel(o.File.Level),
+			Level:     file.Level,
 		})
 		writers = append(writers, fileWriter)
+		byName[sinkFile] = file
 	}
 
-	combinedWriter := io.MultiWriter(writers...)
-
-	l := &logger{
-		option: o,
-		logger: logrus.NewEntry(logrusLogger),
-		writer: combinedWriter,
-		fields: fields,
+	b := &backend{
+		logger:  logrus.NewEntry(logrusLogger),
+		writer:  io.MultiWriter(writers...),
+		writers: writers,
+		byName:  byName,
+		option:  o,
 	}
+	if o.Sampling.Initial > 0 {
+		// um único hook decide pelo registro antes de repassá-lo aos sinks
+		b.sampler = logr.NewSampler(o.Sampling.Initial, o.Sampling.Thereafter, o.Sampling.Interval)
+		hooks = []logrus.Hook{&samplingHook{hooks: hooks, sampler: b.sampler}}
+	}
+	// mascara a mensagem antes de qualquer sink formatá-la
+	logrusLogger.AddHook(maskingHook{})
+	if o.AddSource {
+		logrusLogger.AddHook(callerHook{})
+	}
+	for _, hook := range hooks {
+		logrusLogger.AddHook(hook)
+	}
+	if b.sampler != nil {
+		b.sampler.Report(b.reporter().reportSampling)
+	}
+	return b
+}
+
+// reporter returns a logger that writes straight to b, for the records the
+// backend logs itself.
+func (b *backend) reporter() *logger {
+	l := &logger{root: &atomic.Pointer[backend]{}}
+	l.root.Store(b)
 	return l
 }
 
+// reportSampling logs how many records the sampler suppressed.
+func (l *logger) reportSampling(suppressed uint64) {
+	l.Warnw(logr.SamplingMessage,
+		logr.Uint64("suppressed", suppressed),
+		logr.Duration("interval", l.root.Load().option.Sampling.Interval),
+	)
+}
+
+// Sinks implements config.Backend.
+func (b *backend) Sinks() map[string]config.Sink {
+	return b.byName
+}
+
+// Close implements config.Backend. It stops the sampler and closes the
+// writers as config.Close does.
+func (b *backend) Close() error {
+	if b.sampler != nil {
+		b.sampler.Stop()
+	}
+	return config.Close[*lumberjack.Logger](b.writers)
+}
+
+// fatal ends Fatal once the record is written; see config.Fatal.
+func (b *backend) fatal() {
+	config.Fatal(b.writers, b.option.OnFatal)
+}
+
 func buildFormatter(formatter string) logrus.Formatter {
-	switch formatter {
-	case "JSON":
+	switch buildFormat(formatter) {
+	case logr.FormatJSON:
 		return &logrus.JSONFormatter{}
 	default:
 		return &logrus.TextFormatter{}
 	}
 }
 
+// discardFormatter skips the logger's own output, which is replaced by one
+// WriterHook per sink.
+type discardFormatter struct{}
+
+func (discardFormatter) Format(*logrus.Entry) ([]byte, error) {
+	return nil, nil
+}
+
 func options(fns []FnOption) *Option {
 	option := defaultOption()
 
@@ -191,11 +526,12 @@ func options(fns []FnOption) *Option {
 type WriterHook struct {
 	Writer    io.Writer
 	Formatter logrus.Formatter
-	Level     logrus.Level
+	Level     *logr.AtomicLevel
 }
 
 func (hook *WriterHook) Fire(entry *logrus.Entry) error {
-	if entry.Level < hook.Level {
+	// No logrus, níveis mais severos têm valores menores
+	if entry.Level > toLogrusLevel(hook.Level.Level()) {
 		return nil
 	}
 	formatted, err := hook.Formatter.Format(entry)
@@ -209,3 +545,86 @@ func (hook *WriterHook) Fire(entry *logrus.Entry) error {
 func (hook *WriterHook) Levels() []logrus.Level {
 	return logrus.AllLevels
 }
+
+// samplingHook passes to the sink hooks only the records its sampler keeps.
+type samplingHook struct {
+	hooks   []logrus.Hook
+	sampler *logr.Sampler
+}
+
+func (hook *samplingHook) Fire(entry *logrus.Entry) error {
+	if !hook.sampler.Sample(fromLogrusLevel(entry.Level), entry.Message) {
+		return nil
+	}
+	var errs []error
+	for _, h := range hook.hooks {
+		errs = append(errs, h.Fire(entry))
+	}
+	return errors.Join(errs...)
+}
+
+func (hook *samplingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// maskingHook masks the message with logr.MaskMessage. It is added before
+// the sink hooks, which format the entry it changed; fields are masked when
+// they are built.
+type maskingHook struct{}
+
+func (maskingHook) Fire(entry *logrus.Entry) error {
+	entry.Message = logr.MaskMessage(entry.Message)
+	return nil
+}
+
+func (maskingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// panicWith replaces the *logrus.Entry logrus panics with by message, already
+// masked, the value every logr adapter panics with. It must be deferred.
+func panicWith(message string) {
+	switch r := recover().(type) {
+	case nil:
+	case *logrus.Entry:
+		panic(message)
+	default:
+		panic(r)
+	}
+}
+
+// adapterPrefix is the prefix of the functions of this package, as
+// runtime.Frame.Function reports them.
+var adapterPrefix = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndexByte(name, '/')
+	return name[:slash+strings.IndexByte(name[slash:], '.')+1]
+}()
+
+// callerHook replaces the caller logrus reports, the first frame outside
+// logrus, which is always a method of this adapter, with the first frame
+// outside both.
+type callerHook struct{}
+
+func (callerHook) Fire(entry *logrus.Entry) error {
+	if entry.Caller == nil {
+		return nil
+	}
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, adapterPrefix) && !strings.HasPrefix(frame.Function, "github.com/sirupsen/logrus.") {
+			entry.Caller = &frame
+			return nil
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+func (callerHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
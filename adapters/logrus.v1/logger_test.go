@@ -0,0 +1,91 @@
+package logrus_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/adapters/logrus.v1"
+	"github.com/BrunoTulio/logr/logrtest/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, func(w io.Writer, level logr.Level) logr.Logger {
+		l := logrus.New(logrus.WithConsole(true), logrus.WithConsoleWriter(w), logrus.WithConsoleFormatter("JSON"))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestFatal(t *testing.T) {
+	conformance.RunFatal(t, func(w io.Writer, onFatal func()) logr.Logger {
+		return logrus.New(logrus.WithConsole(true), logrus.WithConsoleWriter(w), logrus.WithConsoleFormatter("JSON"), logrus.WithOnFatal(onFatal))
+	})
+}
+
+func TestSampling(t *testing.T) {
+	conformance.RunSampling(t, func(w io.Writer, initial, thereafter int, interval time.Duration) logr.Logger {
+		return logrus.New(logrus.WithConsole(true), logrus.WithConsoleWriter(w), logrus.WithConsoleFormatter("JSON"), logrus.WithSampling(initial, thereafter, interval))
+	})
+}
+
+func TestContextExtractors(t *testing.T) {
+	conformance.RunContextExtractors(t, func(w io.Writer, level logr.Level, extractor logr.ContextExtractor) logr.Logger {
+		l := logrus.New(logrus.WithConsole(true), logrus.WithConsoleWriter(w), logrus.WithConsoleFormatter("JSON"), logrus.WithContextExtractor(extractor))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestCaller(t *testing.T) {
+	conformance.RunCaller(t, func(w io.Writer) logr.Logger {
+		return logrus.New(logrus.WithConsole(true), logrus.WithConsoleWriter(w), logrus.WithConsoleFormatter("JSON"), logrus.WithAddSource(true))
+	})
+}
+
+func TestSinkLevels(t *testing.T) {
+	var console bytes.Buffer
+	dir := t.TempDir()
+	l := logrus.New(
+		logrus.WithConsole(true), logrus.WithConsoleWriter(&console), logrus.WithConsoleFormatter("JSON"), logrus.WithConsoleLevel(logr.LevelWarn),
+		logrus.WithFile(true, dir, "app.log"), logrus.WithFileFormatter("JSON"), logrus.WithFileLevel(logr.LevelDebug),
+	)
+	levels := l.(interface {
+		SinkLevels() map[string]*logr.AtomicLevel
+	}).SinkLevels()
+
+	l.Info("file only")
+	levels["console"].SetLevel(logr.LevelInfo)
+	l.Info("both")
+	l.SetLevel(logr.LevelError)
+	l.Warn("none")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for sink, tt := range map[string]struct {
+		r    io.Reader
+		want []string
+	}{
+		"console": {&console, []string{"both"}},
+		"file":    {file, []string{"file only", "both"}},
+	} {
+		var got []string
+		for _, r := range conformance.Decode(t, tt.r) {
+			got = append(got, r.Message())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s got %q, want %q", sink, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package logrus
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/BrunoTulio/logr"
+)
+
+var _ logr.ObjectEncoder = fieldsEncoder{}
+
+// marshalFields renders a logr.ObjectMarshaler as nested logrus.Fields.
+func marshalFields(object logr.ObjectMarshaler) logrus.Fields {
+	enc := fieldsEncoder{}
+	if err := object.MarshalLogObject(enc); err != nil {
+		enc[logr.ErrorKey] = err.Error()
+	}
+	return logrus.Fields(enc)
+}
+
+// fieldsEncoder collects the fields of a logr.ObjectMarshaler.
+type fieldsEncoder logrus.Fields
+
+func (e fieldsEncoder) AddString(key, value string) {
+	e[key] = value
+}
+
+func (e fieldsEncoder) AddBool(key string, value bool) {
+	e[key] = value
+}
+
+func (e fieldsEncoder) AddInt(key string, value int) {
+	e[key] = value
+}
+
+func (e fieldsEncoder) AddInt64(key string, value int64) {
+	e[key] = value
+}
+
+func (e fieldsEncoder) AddUint64(key string, value uint64) {
+	e[key] = value
+}
+
+func (e fieldsEncoder) AddFloat64(key string, value float64) {
+	e[key] = value
+}
+
+func (e fieldsEncoder) AddTime(key string, value time.Time) {
+	e[key] = value
+}
+
+func (e fieldsEncoder) AddDuration(key string, value time.Duration) {
+	e[key] = value
+}
+
+func (e fieldsEncoder) AddStrings(key string, value []string) {
+	e[key] = value
+}
+
+func (e fieldsEncoder) AddObject(key string, value logr.ObjectMarshaler) error {
+	e[key] = marshalFields(value)
+	return nil
+}
+
+func (e fieldsEncoder) AddAny(key string, value any) {
+	e[key] = value
+}
@@ -0,0 +1,63 @@
+package native
+
+import (
+	"context"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
+)
+
+// adapter gives the shared constructors of the config package the options
+// of this adapter.
+var adapter = config.Adapter[FnOption]{New: New, Options: configOptions}
+
+// NewFromConfig validates cfg and creates the logger it describes. fns are
+// applied after the config, for settings such as WithConsoleWriter that it
+// does not cover.
+func NewFromConfig(cfg config.Config, fns ...FnOption) (logr.Logger, error) {
+	return adapter.NewFromConfig(cfg, fns...)
+}
+
+// NewFromFile creates the logger described by the JSON config file at name and
+// reloads it whenever the file changes or the process receives SIGHUP, until
+// ctx is done. A failed reload is logged and the previous config is kept.
+func NewFromFile(ctx context.Context, name string, fns ...FnOption) (logr.Logger, error) {
+	return adapter.NewFromFile(ctx, name, fns...)
+}
+
+// FromEnv returns the options described by the environment variables named
+// with prefix, such as LOG_LEVEL and LOG_FILE_MAX_SIZE (see config.FromEnv).
+// Unknown levels and formats and malformed numbers are errors.
+func FromEnv(prefix string) ([]FnOption, error) {
+	return adapter.FromEnv(prefix)
+}
+
+// configOptions translates cfg into the options of the adapter.
+func configOptions(cfg config.Config) []FnOption {
+	return []FnOption{
+		WithConsole(cfg.Console.Enabled),
+		WithConsoleLevel(cfg.Console.Level),
+		WithConsoleFormatter(cfg.Console.Format),
+		WithFile(cfg.File.Enabled, cfg.File.Path, cfg.File.Name),
+		WithFileLevel(cfg.File.Level),
+		WithFileFormatter(cfg.File.Format),
+		WithFileRotation(cfg.File.MaxSize, cfg.File.MaxAge, cfg.File.Compress),
+		WithAddSource(cfg.Caller),
+		WithSampling(cfg.Sampling.Initial, cfg.Sampling.Thereafter, time.Duration(cfg.Sampling.Interval)),
+	}
+}
+
+// Reload implements config.Reloader. Options cfg does not cover, such as
+// WithConsoleWriter and WithContextExtractor, are kept, and so are the levels
+// changed at run time of the sinks whose level cfg leaves alone (see
+// config.Reload).
+func (l *logger) Reload(cfg config.Config) error {
+	return config.Reload(l.root, cfg, func(current *backend) *backend {
+		option := *current.option
+		for _, fn := range configOptions(cfg) {
+			fn(&option)
+		}
+		return newBackend(&option)
+	})
+}
@@ -0,0 +1,494 @@
+package native
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/BrunoTulio/logr"
+)
+
+// timeFormat is used for the time of every record.
+const timeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+const hexDigits = "0123456789abcdef"
+
+// encoder appends records in one output format to a buffer. Strings, numbers,
+// bools, times, durations (JSON), hex and groups are encoded straight into
+// the buffer without allocating; the other types go through fmt or
+// encoding/json.
+type encoder interface {
+	// begin opens a record; file is empty when the caller is not logged.
+	begin(buf []byte, t time.Time, level logr.Level, message, file string, line int) []byte
+	// fields appends fields, which the logger has already redacted once for
+	// every sink.
+	fields(buf []byte, fields logr.Fields) []byte
+	end(buf []byte) []byte
+}
+
+func buildEncoder(formatter string) encoder {
+	switch buildFormat(formatter) {
+	case logr.FormatJSON:
+		return jsonEncoder{}
+	default:
+		return textEncoder{}
+	}
+}
+
+// jsonEncoder writes one JSON object per record.
+type jsonEncoder struct{}
+
+func (jsonEncoder) begin(buf []byte, t time.Time, level logr.Level, message, file string, line int) []byte {
+	buf = append(buf, `{"time":"`...)
+	buf = t.AppendFormat(buf, timeFormat)
+	buf = append(buf, `","level":"`...)
+	buf = append(buf, level.String()...)
+	buf = append(buf, '"')
+	if file != "" {
+		buf = append(buf, `,"caller":"`...)
+		buf = appendJSONEscaped(buf, file)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(line), 10)
+		buf = append(buf, '"')
+	}
+	buf = append(buf, `,"msg":`...)
+	return appendJSONString(buf, message)
+}
+
+func (e jsonEncoder) fields(buf []byte, fields logr.Fields) []byte {
+	for _, f := range fields {
+		buf = e.field(buf, f)
+	}
+	return buf
+}
+
+func (jsonEncoder) end(buf []byte) []byte {
+	return append(buf, '}', '\n')
+}
+
+// key appends ,"key<suffix>": so every member, including the first one of an
+// object, starts with a comma; closeObject drops the extra one.
+func (jsonEncoder) key(buf []byte, key, suffix string) []byte {
+	buf = append(buf, ',', '"')
+	buf = appendJSONEscaped(buf, key)
+	buf = appendJSONEscaped(buf, suffix)
+	return append(buf, '"', ':')
+}
+
+// field appends f natively. A value that does not match its FieldType (e.g. a
+// Field built by hand) is encoded as with Any instead of panicking.
+func (e jsonEncoder) field(buf []byte, f logr.Field) []byte {
+	switch f.Type {
+	case logr.StringType:
+		if v, ok := f.Value.(string); ok {
+			return appendJSONString(e.key(buf, f.Key, ""), v)
+		}
+	case logr.BoolType:
+		if v, ok := f.Value.(bool); ok {
+			return strconv.AppendBool(e.key(buf, f.Key, ""), v)
+		}
+	case logr.IntType:
+		if v, ok := f.Value.(int); ok {
+			return strconv.AppendInt(e.key(buf, f.Key, ""), int64(v), 10)
+		}
+	case logr.Int64Type:
+		if v, ok := f.Value.(int64); ok {
+			return strconv.AppendInt(e.key(buf, f.Key, ""), v, 10)
+		}
+	case logr.Int32Type:
+		if v, ok := f.Value.(int32); ok {
+			return strconv.AppendInt(e.key(buf, f.Key, ""), int64(v), 10)
+		}
+	case logr.UintType:
+		if v, ok := f.Value.(uint); ok {
+			return strconv.AppendUint(e.key(buf, f.Key, ""), uint64(v), 10)
+		}
+	case logr.Uint32Type:
+		if v, ok := f.Value.(uint32); ok {
+			return strconv.AppendUint(e.key(buf, f.Key, ""), uint64(v), 10)
+		}
+	case logr.Uint64Type:
+		if v, ok := f.Value.(uint64); ok {
+			return strconv.AppendUint(e.key(buf, f.Key, ""), v, 10)
+		}
+	case logr.Float32Type:
+		if v, ok := f.Value.(float32); ok {
+			return appendJSONFloat(e.key(buf, f.Key, ""), float64(v), 32)
+		}
+	case logr.Float64Type:
+		if v, ok := f.Value.(float64); ok {
+			return appendJSONFloat(e.key(buf, f.Key, ""), v, 64)
+		}
+	case logr.TimeType:
+		if v, ok := f.Value.(time.Time); ok {
+			buf = append(e.key(buf, f.Key, ""), '"')
+			return append(v.AppendFormat(buf, time.RFC3339Nano), '"')
+		}
+	case logr.DurationType:
+		if v, ok := f.Value.(time.Duration); ok {
+			return strconv.AppendInt(e.key(buf, f.Key, ""), int64(v), 10)
+		}
+	case logr.BytesType:
+		if v, ok := f.Value.([]byte); ok {
+			buf = append(e.key(buf, f.Key, ""), '"')
+			return append(base64.StdEncoding.AppendEncode(buf, v), '"')
+		}
+	case logr.HexType:
+		if v, ok := f.Value.([]byte); ok {
+			buf = append(e.key(buf, f.Key, ""), '"')
+			return append(hex.AppendEncode(buf, v), '"')
+		}
+	case logr.StringsType:
+		if v, ok := f.Value.([]string); ok {
+			buf = append(e.key(buf, f.Key, ""), '[')
+			for i, s := range v {
+				if i > 0 {
+					buf = append(buf, ',')
+				}
+				buf = appendJSONString(buf, s)
+			}
+			return append(buf, ']')
+		}
+	case logr.IntsType:
+		if v, ok := f.Value.([]int); ok {
+			buf = append(e.key(buf, f.Key, ""), '[')
+			for i, n := range v {
+				if i > 0 {
+					buf = append(buf, ',')
+				}
+				buf = strconv.AppendInt(buf, int64(n), 10)
+			}
+			return append(buf, ']')
+		}
+	case logr.StringerType:
+		if v, ok := f.Value.(fmt.Stringer); ok {
+			// fmt.Sprint trata receivers nil como "<nil>"
+			return appendJSONString(e.key(buf, f.Key, ""), fmt.Sprint(v))
+		}
+	case logr.GroupType:
+		if v, ok := f.Value.([]logr.Field); ok {
+			return e.object(e.key(buf, f.Key, ""), v)
+		}
+	case logr.ObjectType:
+		if v, ok := f.Value.(logr.ObjectMarshaler); ok {
+			return e.object(e.key(buf, f.Key, ""), objectFields(v))
+		}
+	case logr.ErrorType:
+		if err, ok := f.Value.(error); ok {
+			return e.error(buf, f.Key, err)
+		}
+		if f.Value == nil {
+			return buf
+		}
+	case logr.SecretType:
+		return appendJSONString(e.key(buf, f.Key, ""), logr.RedactedValue)
+	case logr.AnyType, logr.LazyType, logr.LazyGroupType:
+		// Any é a codificação genérica; campos preguiçosos chegam já resolvidos
+	}
+	return e.any(e.key(buf, f.Key, ""), f.Value)
+}
+
+func (e jsonEncoder) object(buf []byte, fields logr.Fields) []byte {
+	start := len(buf)
+	// os campos aninhados já passaram por logr.RedactFields
+	buf = append(buf, '{')
+	for _, f := range fields {
+		buf = e.field(buf, f)
+	}
+	return closeObject(buf, start)
+}
+
+// error appends the message of err, its chain and, when available, its
+// verbose rendering, as the other adapters do.
+func (e jsonEncoder) error(buf []byte, key string, err error) []byte {
+	buf = appendJSONString(e.key(buf, key, ""), err.Error())
+
+	buf = append(e.key(buf, key, logr.ErrorChainSuffix), '[')
+	for i, link := range logr.ErrorChain(err) {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, `{"message":`...)
+		buf = appendJSONString(buf, link.Message)
+		buf = append(buf, `,"type":`...)
+		buf = appendJSONString(buf, link.Type)
+		buf = append(buf, '}')
+	}
+	buf = append(buf, ']')
+
+	if verbose := logr.ErrorVerbose(err); verbose != "" {
+		buf = appendJSONString(e.key(buf, key, logr.ErrorVerboseSuffix), verbose)
+	}
+	return buf
+}
+
+func (jsonEncoder) any(buf []byte, v any) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return appendJSONString(buf, fmt.Sprintf("%+v", v))
+	}
+	return append(buf, b...)
+}
+
+// closeObject ends the object opened at start, dropping the comma written
+// before its first member.
+func closeObject(buf []byte, start int) []byte {
+	if len(buf) > start+1 {
+		buf = append(buf[:start+1], buf[start+2:]...)
+	}
+	return append(buf, '}')
+}
+
+func appendJSONString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	buf = appendJSONEscaped(buf, s)
+	return append(buf, '"')
+}
+
+// appendJSONEscaped escapes s as encoding/json does, without HTML escaping.
+func appendJSONEscaped(buf []byte, s string) []byte {
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				buf = append(buf, '\\', c)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, `\ufffd`...)
+		case r == '\u2028' || r == '\u2029':
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	return append(buf, s[start:]...)
+}
+
+// appendJSONFloat formats f as encoding/json does; NaN and infinities, which
+// JSON cannot hold, are written as strings.
+func appendJSONFloat(buf []byte, f float64, bits int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(buf, `"NaN"`...)
+	case math.IsInf(f, 1):
+		return append(buf, `"+Inf"`...)
+	case math.IsInf(f, -1):
+		return append(buf, `"-Inf"`...)
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	buf = strconv.AppendFloat(buf, f, format, -1, bits)
+	if format == 'e' {
+		// e-09 -> e-9
+		if n := len(buf); n >= 4 && buf[n-4] == 'e' && buf[n-3] == '-' && buf[n-2] == '0' {
+			buf[n-2] = buf[n-1]
+			buf = buf[:n-1]
+		}
+	}
+	return buf
+}
+
+// textEncoder writes key=value records, quoting values when needed and
+// flattening groups into dotted keys, like the TEXT formatter of the slog
+// adapter.
+type textEncoder struct{}
+
+func (textEncoder) begin(buf []byte, t time.Time, level logr.Level, message, file string, line int) []byte {
+	buf = append(buf, "time="...)
+	buf = t.AppendFormat(buf, timeFormat)
+	buf = append(buf, " level="...)
+	buf = append(buf, level.String()...)
+	if file != "" {
+		buf = append(buf, " caller="...)
+		buf = append(buf, file...)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(line), 10)
+	}
+	buf = append(buf, " msg="...)
+	return appendTextString(buf, message)
+}
+
+func (e textEncoder) fields(buf []byte, fields logr.Fields) []byte {
+	for _, f := range fields {
+		buf = e.field(buf, nil, f)
+	}
+	return buf
+}
+
+func (textEncoder) end(buf []byte) []byte {
+	return append(buf, '\n')
+}
+
+// key appends " group.key<suffix>=".
+func (textEncoder) key(buf []byte, groups []string, key, suffix string) []byte {
+	buf = append(buf, ' ')
+	for _, group := range groups {
+		buf = appendTextString(buf, group)
+		buf = append(buf, '.')
+	}
+	buf = appendTextString(buf, key+suffix)
+	return append(buf, '=')
+}
+
+// field appends f natively, falling back to Any like jsonEncoder.field.
+func (e textEncoder) field(buf []byte, groups []string, f logr.Field) []byte {
+	switch f.Type {
+	case logr.StringType:
+		if v, ok := f.Value.(string); ok {
+			return appendTextString(e.key(buf, groups, f.Key, ""), v)
+		}
+	case logr.BoolType:
+		if v, ok := f.Value.(bool); ok {
+			return strconv.AppendBool(e.key(buf, groups, f.Key, ""), v)
+		}
+	case logr.IntType:
+		if v, ok := f.Value.(int); ok {
+			return strconv.AppendInt(e.key(buf, groups, f.Key, ""), int64(v), 10)
+		}
+	case logr.Int64Type:
+		if v, ok := f.Value.(int64); ok {
+			return strconv.AppendInt(e.key(buf, groups, f.Key, ""), v, 10)
+		}
+	case logr.Int32Type:
+		if v, ok := f.Value.(int32); ok {
+			return strconv.AppendInt(e.key(buf, groups, f.Key, ""), int64(v), 10)
+		}
+	case logr.UintType:
+		if v, ok := f.Value.(uint); ok {
+			return strconv.AppendUint(e.key(buf, groups, f.Key, ""), uint64(v), 10)
+		}
+	case logr.Uint32Type:
+		if v, ok := f.Value.(uint32); ok {
+			return strconv.AppendUint(e.key(buf, groups, f.Key, ""), uint64(v), 10)
+		}
+	case logr.Uint64Type:
+		if v, ok := f.Value.(uint64); ok {
+			return strconv.AppendUint(e.key(buf, groups, f.Key, ""), v, 10)
+		}
+	case logr.Float32Type:
+		if v, ok := f.Value.(float32); ok {
+			return strconv.AppendFloat(e.key(buf, groups, f.Key, ""), float64(v), 'g', -1, 32)
+		}
+	case logr.Float64Type:
+		if v, ok := f.Value.(float64); ok {
+			return strconv.AppendFloat(e.key(buf, groups, f.Key, ""), v, 'g', -1, 64)
+		}
+	case logr.TimeType:
+		if v, ok := f.Value.(time.Time); ok {
+			return v.AppendFormat(e.key(buf, groups, f.Key, ""), timeFormat)
+		}
+	case logr.DurationType:
+		if v, ok := f.Value.(time.Duration); ok {
+			return append(e.key(buf, groups, f.Key, ""), v.String()...)
+		}
+	case logr.BytesType:
+		if v, ok := f.Value.([]byte); ok {
+			return appendTextString(e.key(buf, groups, f.Key, ""), base64.StdEncoding.EncodeToString(v))
+		}
+	case logr.HexType:
+		if v, ok := f.Value.([]byte); ok {
+			return hex.AppendEncode(e.key(buf, groups, f.Key, ""), v)
+		}
+	case logr.StringerType:
+		if v, ok := f.Value.(fmt.Stringer); ok {
+			return appendTextString(e.key(buf, groups, f.Key, ""), fmt.Sprint(v))
+		}
+	case logr.GroupType:
+		if v, ok := f.Value.([]logr.Field); ok {
+			return e.group(buf, groups, f.Key, v)
+		}
+	case logr.ObjectType:
+		if v, ok := f.Value.(logr.ObjectMarshaler); ok {
+			return e.group(buf, groups, f.Key, objectFields(v))
+		}
+	case logr.ErrorType:
+		if err, ok := f.Value.(error); ok {
+			return e.error(buf, groups, f.Key, err)
+		}
+		if f.Value == nil {
+			return buf
+		}
+	case logr.SecretType:
+		return appendTextString(e.key(buf, groups, f.Key, ""), logr.RedactedValue)
+	case logr.StringsType, logr.IntsType, logr.AnyType, logr.LazyType, logr.LazyGroupType:
+		// fmt já é a codificação desses tipos em TEXT
+	}
+	return appendTextString(e.key(buf, groups, f.Key, ""), fmt.Sprintf("%+v", f.Value))
+}
+
+func (e textEncoder) group(buf []byte, groups []string, key string, fields logr.Fields) []byte {
+	// Copia para que grupos irmãos não compartilhem o mesmo array
+	groups = append(groups[:len(groups):len(groups)], key)
+	for _, f := range fields {
+		buf = e.field(buf, groups, f)
+	}
+	return buf
+}
+
+func (e textEncoder) error(buf []byte, groups []string, key string, err error) []byte {
+	buf = appendTextString(e.key(buf, groups, key, ""), err.Error())
+	buf = appendTextString(e.key(buf, groups, key, logr.ErrorChainSuffix), fmt.Sprintf("%+v", logr.ErrorChain(err)))
+	if verbose := logr.ErrorVerbose(err); verbose != "" {
+		buf = appendTextString(e.key(buf, groups, key, logr.ErrorVerboseSuffix), verbose)
+	}
+	return buf
+}
+
+// appendTextString quotes s when it is empty or holds spaces, '=', '"' or
+// non-printable characters.
+func appendTextString(buf []byte, s string) []byte {
+	if needsQuoting(s) {
+		return strconv.AppendQuote(buf, s)
+	}
+	return append(buf, s...)
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r == ' ' || r == '=' || r == '"' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package native
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	megabyte = 1024 * 1024
+
+	// backupTimeFormat is added to the name of rotated files, as in
+	// app-2024-01-02T15-04-05.000.log.
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+var _ io.WriteCloser = (*fileWriter)(nil)
+
+// fileWriter appends to a file, rotating it once it would grow past maxSize
+// megabytes. Rotated files older than maxAge days are removed and, with
+// compress, the others are gzipped. It replaces lumberjack without adding a
+// dependency to the module.
+type fileWriter struct {
+	mu       sync.Mutex
+	filename string
+	maxSize  int64
+	maxAge   time.Duration
+	compress bool
+
+	file *os.File
+	size int64
+}
+
+func newFileWriter(filename string, maxSizeMB, maxAgeDays int, compress bool) *fileWriter {
+	return &fileWriter{
+		filename: filename,
+		maxSize:  int64(maxSizeMB) * megabyte,
+		maxAge:   time.Duration(maxAgeDays) * 24 * time.Hour,
+		compress: compress,
+	}
+}
+
+// Write implements io.Writer.
+func (w *fileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Sync commits the current file to disk.
+func (w *fileWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+// Close implements io.Closer.
+func (w *fileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *fileWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.filename), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	w.file, w.size = file, info.Size()
+	return nil
+}
+
+func (w *fileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+
+	ext := filepath.Ext(w.filename)
+	backup := strings.TrimSuffix(w.filename, ext) + "-" + time.Now().Format(backupTimeFormat) + ext
+	if err := os.Rename(w.filename, backup); err != nil {
+		return err
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	w.cleanup(backup)
+	return nil
+}
+
+// cleanup removes expired backups and compresses the one just rotated. Its
+// errors are ignored: losing an old backup must not stop the logging.
+func (w *fileWriter) cleanup(backup string) {
+	if w.maxAge > 0 {
+		ext := filepath.Ext(w.filename)
+		pattern := strings.TrimSuffix(w.filename, ext) + "-*" + ext + "*"
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && time.Since(info.ModTime()) > w.maxAge {
+				_ = os.Remove(match)
+			}
+		}
+	}
+
+	if w.compress {
+		if err := gzipFile(backup); err == nil {
+			_ = os.Remove(backup)
+		}
+	}
+}
+
+func gzipFile(name string) (err error) {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		return err
+	}
+	return gz.Close()
+}
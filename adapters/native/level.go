@@ -0,0 +1,26 @@
+package native
+
+import "github.com/BrunoTulio/logr"
+
+const (
+	sinkConsole = "console"
+	sinkFile    = "file"
+)
+
+// buildLevel returns the level named level, or INFO when it is empty or
+// invalid.
+func buildLevel(level string) logr.Level {
+	if parsed, err := logr.ParseLevel(level); err == nil {
+		return parsed
+	}
+	return logr.LevelInfo
+}
+
+// buildFormat returns the format named format, or TEXT when it is empty or
+// invalid.
+func buildFormat(format string) logr.Format {
+	if parsed, err := logr.ParseFormat(format); err == nil {
+		return parsed
+	}
+	return logr.FormatText
+}
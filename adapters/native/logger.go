@@ -0,0 +1,571 @@
+// Package native is the logr backend with no dependencies outside the
+// standard library. Records are encoded as JSON or TEXT straight into pooled
+// buffers, and the fields given to WithFields are encoded once, when the
+// derived logger is created.
+package native
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
+)
+
+type ctxKey struct{}
+
+var (
+	_ logr.Logger      = (*logger)(nil)
+	_ logr.DropCounter = (*logger)(nil)
+)
+
+type (
+	logger struct {
+		// root is shared by every logger derived from the same New and is
+		// replaced by Reload.
+		root   *atomic.Pointer[backend]
+		bound  atomic.Pointer[bound]
+		fields logr.Fields
+		lazy   logr.Fields
+	}
+
+	// backend is what Reload rebuilds from a new config.
+	backend struct {
+		sinks   []sink
+		writer  io.Writer
+		writers []io.Writer
+		byName  map[string]config.Sink
+		option  *Option
+		// sampler is nil unless WithSampling was given.
+		sampler *logr.Sampler
+	}
+
+	// bound is the backend with the eager fields of one logger encoded in
+	// its sinks.
+	bound struct {
+		backend *backend
+		sinks   []sink
+	}
+
+	// sink is one destination of the records. Derived loggers copy it with
+	// their own encoded fields and share everything else.
+	sink struct {
+		level   *logr.AtomicLevel
+		encoder encoder
+		writer  io.Writer
+		mu      *sync.Mutex
+		// encoded holds the eager fields of the logger, already encoded.
+		encoded []byte
+	}
+)
+
+// maxPooledBuffer keeps buffers grown by unusually large records out of the
+// pool.
+const maxPooledBuffer = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 1024)
+		return &buf
+	},
+}
+
+// Info implements logr.Logger.
+func (l *logger) Info(message string) {
+	l.log(logr.LevelInfo, message, nil)
+}
+
+// Infof implements logr.Logger.
+func (l *logger) Infof(format string, args ...interface{}) {
+	if l.Enabled(logr.LevelInfo) {
+		l.log(logr.LevelInfo, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+// InfoContext implements logr.Logger.
+func (l *logger) InfoContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelInfo) {
+		l.log(logr.LevelInfo, message, l.contextFields(ctx))
+	}
+}
+
+// InfofContext implements logr.Logger.
+func (l *logger) InfofContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelInfo) {
+		l.log(logr.LevelInfo, fmt.Sprintf(format, args...), l.contextFields(ctx))
+	}
+}
+
+// Infow implements logr.Logger.
+func (l *logger) Infow(message string, fields ...logr.Field) {
+	l.log(logr.LevelInfo, message, fields)
+}
+
+// Warn implements logr.Logger.
+func (l *logger) Warn(message string) {
+	l.log(logr.LevelWarn, message, nil)
+}
+
+// Warnf implements logr.Logger.
+func (l *logger) Warnf(format string, args ...interface{}) {
+	if l.Enabled(logr.LevelWarn) {
+		l.log(logr.LevelWarn, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+// WarnContext implements logr.Logger.
+func (l *logger) WarnContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelWarn) {
+		l.log(logr.LevelWarn, message, l.contextFields(ctx))
+	}
+}
+
+// WarnfContext implements logr.Logger.
+func (l *logger) WarnfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelWarn) {
+		l.log(logr.LevelWarn, fmt.Sprintf(format, args...), l.contextFields(ctx))
+	}
+}
+
+// Warnw implements logr.Logger.
+func (l *logger) Warnw(message string, fields ...logr.Field) {
+	l.log(logr.LevelWarn, message, fields)
+}
+
+// Debug implements logr.Logger.
+func (l *logger) Debug(message string) {
+	l.log(logr.LevelDebug, message, nil)
+}
+
+// Debugf implements logr.Logger.
+func (l *logger) Debugf(format string, args ...interface{}) {
+	if l.Enabled(logr.LevelDebug) {
+		l.log(logr.LevelDebug, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+// DebugContext implements logr.Logger.
+func (l *logger) DebugContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelDebug) {
+		l.log(logr.LevelDebug, message, l.contextFields(ctx))
+	}
+}
+
+// DebugfContext implements logr.Logger.
+func (l *logger) DebugfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelDebug) {
+		l.log(logr.LevelDebug, fmt.Sprintf(format, args...), l.contextFields(ctx))
+	}
+}
+
+// Debugw implements logr.Logger.
+func (l *logger) Debugw(message string, fields ...logr.Field) {
+	l.log(logr.LevelDebug, message, fields)
+}
+
+// Error implements logr.Logger.
+func (l *logger) Error(message string) {
+	l.log(logr.LevelError, message, nil)
+}
+
+// Errorf implements logr.Logger.
+func (l *logger) Errorf(format string, args ...interface{}) {
+	if l.Enabled(logr.LevelError) {
+		l.log(logr.LevelError, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+// ErrorContext implements logr.Logger.
+func (l *logger) ErrorContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelError) {
+		l.log(logr.LevelError, message, l.contextFields(ctx))
+	}
+}
+
+// ErrorfContext implements logr.Logger.
+func (l *logger) ErrorfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelError) {
+		l.log(logr.LevelError, fmt.Sprintf(format, args...), l.contextFields(ctx))
+	}
+}
+
+// Errorw implements logr.Logger.
+func (l *logger) Errorw(message string, fields ...logr.Field) {
+	l.log(logr.LevelError, message, fields)
+}
+
+// Fatal implements logr.Logger.
+func (l *logger) Fatal(message string) {
+	l.log(logr.LevelFatal, message, nil)
+	l.root.Load().fatal()
+}
+
+// Fatalf implements logr.Logger.
+func (l *logger) Fatalf(format string, args ...interface{}) {
+	l.log(logr.LevelFatal, fmt.Sprintf(format, args...), nil)
+	l.root.Load().fatal()
+}
+
+// Panic implements logr.Logger.
+func (l *logger) Panic(message string) {
+	// o valor do pânico também sai do logger, então vai mascarado
+	message = logr.MaskMessage(message)
+	l.log(logr.LevelPanic, message, nil)
+	panic(message)
+}
+
+// Panicf implements logr.Logger.
+func (l *logger) Panicf(format string, args ...interface{}) {
+	message := logr.MaskMessage(fmt.Sprintf(format, args...))
+	l.log(logr.LevelPanic, message, nil)
+	panic(message)
+}
+
+// Trace implements logr.Logger.
+func (l *logger) Trace(message string) {
+	l.log(logr.LevelTrace, message, nil)
+}
+
+// Tracef implements logr.Logger.
+func (l *logger) Tracef(format string, args ...interface{}) {
+	if l.Enabled(logr.LevelTrace) {
+		l.log(logr.LevelTrace, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+// Log implements logr.Logger.
+func (l *logger) Log(level logr.Level, message string, fields ...logr.Field) {
+	if level == logr.LevelPanic {
+		message = logr.MaskMessage(message)
+	}
+	l.log(level, message, fields)
+	switch level {
+	case logr.LevelPanic:
+		panic(message)
+	case logr.LevelFatal:
+		l.root.Load().fatal()
+	}
+}
+
+// FromContext implements logr.Logger.
+func (l *logger) FromContext(ctx context.Context) logr.Logger {
+	return l.withContext(ctx)
+}
+
+// GetFields implements logr.Logger.
+func (l *logger) GetFields() logr.Fields {
+	return l.fields
+}
+
+// Level implements logr.Logger.
+func (l *logger) Level() logr.Level {
+	return config.LowestLevel(l.root.Load().byName)
+}
+
+// SetLevel implements logr.Logger.
+func (l *logger) SetLevel(level logr.Level) {
+	config.SetLevel(l.root.Load().byName, level)
+}
+
+// Enabled implements logr.Logger.
+func (l *logger) Enabled(level logr.Level) bool {
+	return config.Enabled(l.root.Load().byName, level)
+}
+
+// SinkLevels returns the level of every enabled sink, keyed by sink name
+// ("console", "file"). Changing a returned level affects every logger derived
+// from l.
+func (l *logger) SinkLevels() map[string]*logr.AtomicLevel {
+	return config.SinkLevels(l.root.Load().byName)
+}
+
+// AsyncDropped implements logr.DropCounter. Only the sinks written through
+// WithAsync are included, and their counts survive Reload.
+func (l *logger) AsyncDropped() map[string]uint64 {
+	return config.Dropped(l.root.Load().byName)
+}
+
+// Output implements logr.Logger.
+func (l *logger) Output() io.Writer {
+	return l.root.Load().writer
+}
+
+// Sync implements logr.Logger.
+func (l *logger) Sync() error {
+	return config.Sync(l.root.Load().writers)
+}
+
+// Close implements logr.Logger.
+func (l *logger) Close() error {
+	return l.root.Load().Close()
+}
+
+// ToContext implements logr.Logger.
+func (l *logger) ToContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l.fields)
+}
+
+// WithField implements logr.Logger.
+func (l *logger) WithField(field logr.Field) logr.Logger {
+	return l.WithFields(field)
+}
+
+// WithFields implements logr.Logger.
+func (l *logger) WithFields(fields ...logr.Field) logr.Logger {
+	eager, lazy := logr.SplitLazy(fields)
+
+	parent := l.bind()
+	derived := &logger{
+		root:   l.root,
+		fields: slices.Concat(l.fields, fields),
+		lazy:   slices.Concat(l.lazy, lazy),
+	}
+	derived.bound.Store(&bound{backend: parent.backend, sinks: encodeFields(parent.sinks, eager)})
+	return derived
+}
+
+// bind returns the sinks with the eager fields of l encoded, encoding them
+// again when a Reload replaced the backend they were bound to.
+func (l *logger) bind() *bound {
+	current := l.root.Load()
+	if b := l.bound.Load(); b != nil && b.backend == current {
+		return b
+	}
+	eager, _ := logr.SplitLazy(l.fields)
+	b := &bound{backend: current, sinks: encodeFields(current.sinks, eager)}
+	l.bound.Store(b)
+	return b
+}
+
+// encodeFields returns a copy of sinks with fields appended to the encoded
+// fields of each one.
+func encodeFields(sinks []sink, fields logr.Fields) []sink {
+	// redige uma vez só, para o Masker não contar cada sink
+	fields = logr.RedactFields(fields)
+	sinks = slices.Clone(sinks)
+	for i := range sinks {
+		sinks[i].encoded = sinks[i].encoder.fields(slices.Clip(sinks[i].encoded), fields)
+	}
+	return sinks
+}
+
+// log writes the record to every sink enabled for level. It must be called
+// straight from the logging methods, so the caller is found at a fixed depth.
+func (l *logger) log(level logr.Level, message string, fields logr.Fields) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	b := l.bind()
+	if sampler := b.backend.sampler; sampler != nil && !sampler.Sample(level, message) {
+		return
+	}
+	message = logr.MaskMessage(message)
+	var (
+		file string
+		line int
+	)
+	if b.backend.option.AddSource {
+		// log <- método de log <- chamador
+		_, file, line, _ = runtime.Caller(2)
+		file = shortCaller(file)
+	}
+
+	now := time.Now()
+	var lazy logr.Fields
+	if len(l.lazy) > 0 {
+		lazy = logr.Resolve(l.lazy)
+	}
+	if slices.ContainsFunc(fields, logr.Field.IsLazy) {
+		fields = logr.Resolve(fields)
+	}
+	// uma vez por registro, como em encodeFields
+	lazy = logr.RedactFields(lazy)
+	fields = logr.RedactFields(fields)
+
+	for i := range b.sinks {
+		s := &b.sinks[i]
+		if !s.level.Enabled(level) {
+			continue
+		}
+
+		buf := bufferPool.Get().(*[]byte)
+		b := s.encoder.begin((*buf)[:0], now, level, message, file, line)
+		b = append(b, s.encoded...)
+		b = s.encoder.fields(b, lazy)
+		b = s.encoder.fields(b, fields)
+		b = s.encoder.end(b)
+
+		s.mu.Lock()
+		_, _ = s.writer.Write(b)
+		s.mu.Unlock()
+
+		if cap(b) <= maxPooledBuffer {
+			*buf = b
+			bufferPool.Put(buf)
+		}
+	}
+}
+
+// withContext returns l extended with the fields stored in ctx by ToContext
+// and those returned by the configured context extractors.
+func (l *logger) withContext(ctx context.Context) *logger {
+	fields := l.contextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.WithFields(fields...).(*logger)
+}
+
+// contextFields returns the fields stored in ctx by ToContext followed by
+// those returned by the context extractors, without the keys l already
+// carries (see logr.ContextFields). The *Context methods log them with the
+// record, without deriving a logger.
+func (l *logger) contextFields(ctx context.Context) logr.Fields {
+	stored, _ := ctx.Value(ctxKey{}).(logr.Fields)
+	return logr.ContextFields(ctx, stored, l.fields, l.root.Load().option.ContextExtractors)
+}
+
+// shortCaller keeps the last directory and the file name, as in
+// "orders/service.go".
+func shortCaller(file string) string {
+	dir, name := path.Split(file)
+	return path.Join(path.Base(dir), name)
+}
+
+func New(fns ...FnOption) logr.Logger {
+	option := options(fns)
+	return NewWithOption(option)
+}
+
+// NewE is like New, but reports invalid levels and formats instead of
+// falling back to INFO and TEXT.
+func NewE(fns ...FnOption) (logr.Logger, error) {
+	option := options(fns)
+	if err := option.validate(); err != nil {
+		return nil, err
+	}
+	return NewWithOption(option), nil
+}
+
+func NewWithOption(o *Option) logr.Logger {
+	l := newLogger(o)
+	logr.Set(l)
+	return l
+}
+
+func newLogger(o *Option, fields ...logr.Field) *logger {
+	l := &logger{
+		root: &atomic.Pointer[backend]{},
+	}
+	l.root.Store(newBackend(o))
+	if len(fields) > 0 {
+		return l.WithFields(fields...).(*logger)
+	}
+	return l
+}
+
+func newBackend(o *Option) *backend {
+	sinks, writers, byName := buildSinksAndWriters(o)
+	b := &backend{
+		sinks:   sinks,
+		writer:  io.MultiWriter(writers...),
+		writers: writers,
+		byName:  byName,
+		option:  o,
+	}
+	if o.Sampling.Initial > 0 {
+		b.sampler = logr.NewSampler(o.Sampling.Initial, o.Sampling.Thereafter, o.Sampling.Interval)
+		b.sampler.Report(b.reporter().reportSampling)
+	}
+	return b
+}
+
+// reporter returns a logger that writes straight to b, for the records the
+// backend logs itself.
+func (b *backend) reporter() *logger {
+	l := &logger{root: &atomic.Pointer[backend]{}}
+	l.root.Store(b)
+	return l
+}
+
+// reportSampling logs how many records the sampler suppressed.
+func (l *logger) reportSampling(suppressed uint64) {
+	l.Warnw(logr.SamplingMessage,
+		logr.Uint64("suppressed", suppressed),
+		logr.Duration("interval", l.root.Load().option.Sampling.Interval),
+	)
+}
+
+// Sinks implements config.Backend.
+func (b *backend) Sinks() map[string]config.Sink {
+	return b.byName
+}
+
+// Close implements config.Backend. It stops the sampler and closes the
+// writers as config.Close does.
+func (b *backend) Close() error {
+	if b.sampler != nil {
+		b.sampler.Stop()
+	}
+	return config.Close[*fileWriter](b.writers)
+}
+
+// fatal ends Fatal once the record is written; see config.Fatal.
+func (b *backend) fatal() {
+	config.Fatal(b.writers, b.option.OnFatal)
+}
+
+func buildSinksAndWriters(o *Option) ([]sink, []io.Writer, map[string]config.Sink) {
+	var sinks []sink
+	var writers []io.Writer
+	byName := make(map[string]config.Sink)
+
+	if o.Console.Enabled {
+		consoleWriter := o.async(o.consoleWriter())
+		console := config.NewSink(consoleWriter, buildLevel(o.Console.Level))
+		sinks = append(sinks, sink{
+			level:   console.Level,
+			encoder: buildEncoder(o.Console.Formatter),
+			writer:  console.Writer,
+			mu:      &sync.Mutex{},
+		})
+		writers = append(writers, consoleWriter)
+		byName[sinkConsole] = console
+	}
+
+	if o.File.Enabled {
+		fileWriter := o.async(newFileWriter(path.Join(o.File.Path, o.File.Name), o.File.MaxSize, o.File.MaxAge, o.File.Compress))
+		file := config.NewSink(fileWriter, buildLevel(o.File.Level))
+		sinks = append(sinks, sink{
+			level:   file.Level,
+			encoder: buildEncoder(o.File.Formatter),
+			writer:  file.Writer,
+			mu:      &sync.Mutex{},
+		})
+		writers = append(writers, fileWriter)
+		byName[sinkFile] = file
+	}
+
+	if len(writers) == 0 {
+		writers = append(writers, io.Discard)
+	}
+
+	return sinks, writers, byName
+}
+
+func options(fns []FnOption) *Option {
+	option := defaultOption()
+
+	for _, fn := range fns {
+		fn(option)
+	}
+	return option
+}
@@ -0,0 +1,313 @@
+package native_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/adapters/native"
+	"github.com/BrunoTulio/logr/config"
+	"github.com/BrunoTulio/logr/logrtest/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, func(w io.Writer, level logr.Level) logr.Logger {
+		l := native.New(native.WithConsole(true), native.WithConsoleWriter(w), native.WithConsoleFormatter("JSON"))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestText(t *testing.T) {
+	var buf bytes.Buffer
+	l := native.New(native.WithConsole(true), native.WithConsoleWriter(&buf), native.WithConsoleFormatter("TEXT"))
+
+	l.WithFields(logr.Group("http", logr.String("method", "GET"), logr.Int("status", 200))).
+		Infow("request done", logr.String("path", "/a b"), logr.Duration("took", 1500*time.Millisecond), logr.Err(errors.New("boom")))
+
+	line := buf.String()
+	_, rest, ok := strings.Cut(line, " ")
+	if !ok || !strings.HasPrefix(line, "time=") {
+		t.Fatalf("line %q does not start with the time", line)
+	}
+	want := `level=INFO msg="request done" http.method=GET http.status=200 path="/a b" took=1.5s error=boom errorChain="[{Message:boom Type:*errors.errorString}]"` + "\n"
+	if rest != want {
+		t.Errorf("got  %s\nwant %s", rest, want)
+	}
+}
+
+func TestAddSource(t *testing.T) {
+	var buf bytes.Buffer
+	l := native.New(native.WithConsole(true), native.WithConsoleWriter(&buf), native.WithConsoleFormatter("JSON"), native.WithAddSource(true))
+
+	l.Info("here")
+
+	if !strings.Contains(buf.String(), `"caller":"native/logger_test.go:`) {
+		t.Errorf("caller missing in %s", buf.String())
+	}
+}
+
+func TestZeroAllocations(t *testing.T) {
+	l := native.New(native.WithConsole(true), native.WithConsoleWriter(io.Discard), native.WithConsoleFormatter("JSON")).
+		WithFields(logr.String("service", "orders"), logr.Int("shard", 3), logr.Float64("ratio", 0.5))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		l.Info("order created")
+		l.Debug("filtered out")
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations per record, want 0", allocs)
+	}
+}
+
+func TestFileRotation(t *testing.T) {
+	dir := t.TempDir()
+	l := native.New(
+		native.WithFile(true, dir, "app.log"),
+		native.WithFileFormatter("JSON"),
+		native.WithFileRotation(1, 7, true),
+	)
+	defer l.Close()
+
+	payload := strings.Repeat("x", 300<<10)
+	for range 4 {
+		l.Info(payload)
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, "app-*.log.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) == 0 {
+		t.Fatal("no compressed backup after passing MaxSize")
+	}
+	info, err := os.Stat(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > 1<<20 {
+		t.Errorf("app.log has %d bytes, want at most 1MB", info.Size())
+	}
+}
+
+func TestClose(t *testing.T) {
+	dir := t.TempDir()
+	l := native.New(native.WithFile(true, dir, "app.log"), native.WithFileFormatter("JSON"))
+
+	l.Info("last words")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "last words") {
+		t.Errorf("app.log = %q, want the record logged before Close", data)
+	}
+}
+
+func TestReload(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := config.Default()
+	l, err := native.NewFromConfig(cfg, native.WithConsoleWriter(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	derived := l.WithFields(logr.String("service", "orders"))
+
+	cfg.Console = config.Console{Enabled: true, Level: "DEBUG", Format: "JSON"}
+	if err := l.(config.Reloader).Reload(cfg); err != nil {
+		t.Fatal(err)
+	}
+	derived.Debug("after reload")
+
+	records := conformance.Decode(t, &buf)
+	if len(records) != 1 || records[0].Message() != "after reload" || records[0]["service"] != "orders" {
+		t.Errorf("records = %v", records)
+	}
+
+	cfg.Console.Level = "LOUD"
+	if err := l.(config.Reloader).Reload(cfg); err == nil {
+		t.Error("Reload accepted an invalid config")
+	}
+	if !derived.Enabled(logr.LevelDebug) {
+		t.Error("failed Reload replaced the previous config")
+	}
+
+	// o nível mudado em tempo de execução sobrevive a uma config que não o muda
+	l.SetLevel(logr.LevelError)
+	cfg.Console.Level = "DEBUG"
+	cfg.Console.Format = "TEXT"
+	if err := l.(config.Reloader).Reload(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if derived.Enabled(logr.LevelWarn) {
+		t.Error("Reload reverted the level set at run time")
+	}
+}
+
+func TestReloadWhileLogging(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.Default()
+	cfg.Console.Enabled = false
+	cfg.File = config.File{Enabled: true, Level: "INFO", Format: "JSON", Path: dir, Name: "app.log"}
+	l, err := native.NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const writers, records = 4, 200
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range records {
+				l.Info("record")
+			}
+		}()
+	}
+	for range 20 {
+		if err := l.(config.Reloader).Reload(cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if got := len(conformance.Decode(t, file)); got != writers*records {
+		t.Errorf("app.log has %d records, want %d", got, writers*records)
+	}
+}
+
+func TestNewE(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := native.NewE(
+		native.WithConsole(true),
+		native.WithConsoleWriter(&buf),
+		native.WithConsoleLevel(logr.LevelWarn),
+		native.WithConsoleFormatter(logr.FormatJSON),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Info("hidden")
+	l.Warn("shown")
+	if records := conformance.Decode(t, &buf); len(records) != 1 || records[0].Message() != "shown" {
+		t.Errorf("records = %v", records)
+	}
+
+	_, err = native.NewE(native.WithConsoleLevel("warning"), native.WithFileFormatter("Json"))
+	if err != nil {
+		t.Errorf("NewE rejected valid names: %v", err)
+	}
+
+	_, err = native.NewE(native.WithConsoleLevel("verbose"), native.WithFileFormatter("yaml"))
+	if !errors.Is(err, logr.ErrInvalidLevel) || !errors.Is(err, logr.ErrInvalidFormat) {
+		t.Errorf("NewE error = %v", err)
+	}
+}
+
+func TestFatal(t *testing.T) {
+	conformance.RunFatal(t, func(w io.Writer, onFatal func()) logr.Logger {
+		return native.New(native.WithConsole(true), native.WithConsoleWriter(w), native.WithConsoleFormatter("JSON"), native.WithOnFatal(onFatal))
+	})
+}
+
+func TestSampling(t *testing.T) {
+	conformance.RunSampling(t, func(w io.Writer, initial, thereafter int, interval time.Duration) logr.Logger {
+		return native.New(native.WithConsole(true), native.WithConsoleWriter(w), native.WithConsoleFormatter("JSON"), native.WithSampling(initial, thereafter, interval))
+	})
+}
+
+func TestContextExtractors(t *testing.T) {
+	conformance.RunContextExtractors(t, func(w io.Writer, level logr.Level, extractor logr.ContextExtractor) logr.Logger {
+		l := native.New(native.WithConsole(true), native.WithConsoleWriter(w), native.WithConsoleFormatter("JSON"), native.WithContextExtractor(extractor))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestCaller(t *testing.T) {
+	conformance.RunCaller(t, func(w io.Writer) logr.Logger {
+		return native.New(native.WithConsole(true), native.WithConsoleWriter(w), native.WithConsoleFormatter("JSON"), native.WithAddSource(true))
+	})
+}
+
+func TestSinkLevels(t *testing.T) {
+	var console bytes.Buffer
+	dir := t.TempDir()
+	l := native.New(
+		native.WithConsole(true), native.WithConsoleWriter(&console), native.WithConsoleFormatter("JSON"), native.WithConsoleLevel(logr.LevelWarn),
+		native.WithFile(true, dir, "app.log"), native.WithFileFormatter("JSON"), native.WithFileLevel(logr.LevelDebug),
+	)
+	levels := l.(interface {
+		SinkLevels() map[string]*logr.AtomicLevel
+	}).SinkLevels()
+
+	l.Info("file only")
+	levels["console"].SetLevel(logr.LevelInfo)
+	l.Info("both")
+	l.SetLevel(logr.LevelError)
+	l.Warn("none")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for sink, tt := range map[string]struct {
+		r    io.Reader
+		want []string
+	}{
+		"console": {&console, []string{"both"}},
+		"file":    {file, []string{"file only", "both"}},
+	} {
+		var got []string
+		for _, r := range conformance.Decode(t, tt.r) {
+			got = append(got, r.Message())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s got %q, want %q", sink, got, tt.want)
+		}
+	}
+}
+
+func TestMaskOncePerRecord(t *testing.T) {
+	masker := logr.GetMasker()
+	t.Cleanup(func() { logr.SetMasker(masker) })
+	m := logr.NewMasker(logr.EmailRule())
+	logr.SetMasker(m)
+
+	l := native.New(
+		native.WithConsole(true), native.WithConsoleWriter(io.Discard),
+		native.WithFile(true, t.TempDir(), "app.log"),
+	)
+	defer l.Close()
+
+	l.WithFields(logr.String("from", "bob@example.com")).Infow("sent", logr.String("to", "ana@example.com"))
+	// um campo mascarado conta uma vez, qualquer que seja o número de sinks
+	if got := m.Counts()["email"]; got != 2 {
+		t.Errorf("email count = %d with two sinks, want 2: one per field", got)
+	}
+}
@@ -0,0 +1,70 @@
+package native
+
+import (
+	"time"
+
+	"github.com/BrunoTulio/logr"
+)
+
+var _ logr.ObjectEncoder = (*objectEncoder)(nil)
+
+// objectEncoder collects the fields of a logr.ObjectMarshaler, which the
+// encoders then write as a group.
+type objectEncoder struct {
+	fields logr.Fields
+}
+
+// objectFields returns the fields of v; a marshal error is kept under the
+// "error" key of the object.
+func objectFields(v logr.ObjectMarshaler) logr.Fields {
+	enc := &objectEncoder{}
+	if err := v.MarshalLogObject(enc); err != nil {
+		enc.AddString(logr.ErrorKey, err.Error())
+	}
+	return enc.fields
+}
+
+func (e *objectEncoder) AddString(key, value string) {
+	e.fields = append(e.fields, logr.String(key, value))
+}
+
+func (e *objectEncoder) AddBool(key string, value bool) {
+	e.fields = append(e.fields, logr.Bool(key, value))
+}
+
+func (e *objectEncoder) AddInt(key string, value int) {
+	e.fields = append(e.fields, logr.Int(key, value))
+}
+
+func (e *objectEncoder) AddInt64(key string, value int64) {
+	e.fields = append(e.fields, logr.Int64(key, value))
+}
+
+func (e *objectEncoder) AddUint64(key string, value uint64) {
+	e.fields = append(e.fields, logr.Uint64(key, value))
+}
+
+func (e *objectEncoder) AddFloat64(key string, value float64) {
+	e.fields = append(e.fields, logr.Float64(key, value))
+}
+
+func (e *objectEncoder) AddTime(key string, value time.Time) {
+	e.fields = append(e.fields, logr.Time(key, value))
+}
+
+func (e *objectEncoder) AddDuration(key string, value time.Duration) {
+	e.fields = append(e.fields, logr.Duration(key, value))
+}
+
+func (e *objectEncoder) AddStrings(key string, value []string) {
+	e.fields = append(e.fields, logr.Strings(key, value))
+}
+
+func (e *objectEncoder) AddObject(key string, value logr.ObjectMarshaler) error {
+	e.fields = append(e.fields, logr.Object(key, value))
+	return nil
+}
+
+func (e *objectEncoder) AddAny(key string, value any) {
+	e.fields = append(e.fields, logr.Any(key, value))
+}
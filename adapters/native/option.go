@@ -0,0 +1,183 @@
+package native
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
+)
+
+type FnOption func(option *Option)
+
+type Option struct {
+	Console struct {
+		Enabled   bool
+		Level     string
+		Formatter string
+		Writer    io.Writer
+	}
+	File struct {
+		Formatter string
+		Enabled   bool
+		Path      string
+		Name      string
+		MaxSize   int
+		Compress  bool
+		MaxAge    int
+		Level     string
+	}
+	// Async, when BufferSize is set, queues the records of every sink in a
+	// logr.AsyncWriter.
+	Async struct {
+		BufferSize int
+		Policy     logr.OverflowPolicy
+	}
+	// Sampling, when Initial is set, keeps the first Initial records with the
+	// same level and message in each Interval and then one of every
+	// Thereafter.
+	Sampling struct {
+		Initial    int
+		Thereafter int
+		Interval   time.Duration
+	}
+	AddSource         bool
+	ContextExtractors []logr.ContextExtractor
+	// OnFatal runs after Fatal has written its record and flushed the sinks.
+	// When nil, logr.Exit(1) runs the shutdown hooks and ends the process.
+	OnFatal func()
+}
+
+func defaultOption() *Option {
+	return &Option{}
+}
+
+// consoleWriter returns the destination of the console sink, os.Stdout unless
+// WithConsoleWriter was given.
+func (o *Option) consoleWriter() io.Writer {
+	if o.Console.Writer == nil {
+		return os.Stdout
+	}
+	return o.Console.Writer
+}
+
+// async wraps the destination of a sink in a logr.AsyncWriter when WithAsync
+// was given.
+func (o *Option) async(w io.Writer) io.Writer {
+	if o.Async.BufferSize <= 0 {
+		return w
+	}
+	return logr.NewAsyncWriter(w, o.Async.BufferSize, o.Async.Policy)
+}
+
+// validate reports the levels and formats New would replace by INFO and TEXT,
+// and sampling without an interval.
+func (o *Option) validate() error {
+	return errors.Join(
+		config.ValidateLevel("console level", o.Console.Level),
+		config.ValidateFormat("console formatter", o.Console.Formatter),
+		config.ValidateLevel("file level", o.File.Level),
+		config.ValidateFormat("file formatter", o.File.Formatter),
+		config.ValidateSampling(o.Sampling.Initial, o.Sampling.Thereafter, o.Sampling.Interval),
+	)
+}
+
+func WithConsoleLevel[T logr.LevelValue](level T) FnOption {
+	return func(option *Option) {
+		option.Console.Level = fmt.Sprint(level)
+	}
+}
+
+func WithFileLevel[T logr.LevelValue](level T) FnOption {
+	return func(option *Option) {
+		option.File.Level = fmt.Sprint(level)
+	}
+}
+
+func WithConsoleFormatter[T logr.FormatValue](formatter T) FnOption {
+	return func(option *Option) {
+		option.Console.Formatter = fmt.Sprint(formatter)
+	}
+}
+
+func WithFileFormatter[T logr.FormatValue](formatter T) FnOption {
+	return func(option *Option) {
+		option.File.Formatter = fmt.Sprint(formatter)
+	}
+}
+
+func WithConsole(enabled bool) FnOption {
+	return func(option *Option) {
+		option.Console.Enabled = enabled
+	}
+}
+
+// WithConsoleWriter sends the console sink to w instead of os.Stdout.
+func WithConsoleWriter(w io.Writer) FnOption {
+	return func(option *Option) {
+		option.Console.Writer = w
+	}
+}
+
+func WithFile(enabled bool, path, name string) FnOption {
+	return func(option *Option) {
+		option.File.Enabled = enabled
+		option.File.Path = path
+		option.File.Name = name
+	}
+}
+
+func WithFileRotation(maxSize int, maxAge int, compress bool) FnOption {
+	return func(option *Option) {
+		option.File.MaxSize = maxSize
+		option.File.MaxAge = maxAge
+		option.File.Compress = compress
+	}
+}
+
+func WithAddSource(addSource bool) FnOption {
+	return func(option *Option) {
+		option.AddSource = addSource
+	}
+}
+
+// WithContextExtractor adds an extractor whose fields are merged into every
+// record logged through the *Context methods and FromContext.
+func WithContextExtractor(extractor logr.ContextExtractor) FnOption {
+	return func(option *Option) {
+		option.ContextExtractors = append(option.ContextExtractors, extractor)
+	}
+}
+
+// WithOnFatal replaces logr.Exit(1) as what Fatal does once its record is
+// written and the sinks are flushed. Tests use it to keep the process alive;
+// when fn returns, so does Fatal.
+func WithOnFatal(fn func()) FnOption {
+	return func(option *Option) {
+		option.OnFatal = fn
+	}
+}
+
+// WithAsync writes the records of every sink from a background goroutine,
+// queueing up to bufferSize of them; policy says what happens when the queue
+// is full. Close drains the queue.
+func WithAsync(bufferSize int, policy logr.OverflowPolicy) FnOption {
+	return func(option *Option) {
+		option.Async.BufferSize = bufferSize
+		option.Async.Policy = policy
+	}
+}
+
+// WithSampling keeps, in each interval, the first initial records with the
+// same level and message and then one of every thereafter. Once per interval
+// a record with logr.SamplingMessage tells how many were suppressed.
+func WithSampling(initial, thereafter int, interval time.Duration) FnOption {
+	return func(option *Option) {
+		option.Sampling.Initial = initial
+		option.Sampling.Thereafter = thereafter
+		option.Sampling.Interval = interval
+	}
+}
@@ -1,7 +1,11 @@
 package slog
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/BrunoTulio/logr"
@@ -10,39 +14,123 @@ import (
 func buildAttrGroup(fields []logr.Field) []any {
 	result := make([]any, 0, len(fields))
 	for _, f := range fields {
-		result = append(result, buildAttr(f))
+		result = appendAttr(result, f)
 	}
 	return result
 }
 
+// appendAttr appends the attrs of f, which are more than one for ErrorType.
+func appendAttr(result []any, f logr.Field) []any {
+	if f.Type == logr.ErrorType {
+		return appendErrorAttrs(result, f)
+	}
+	return append(result, buildAttr(f))
+}
+
+func appendErrorAttrs(result []any, f logr.Field) []any {
+	err, _ := f.Value.(error)
+	if err == nil {
+		return result
+	}
+	result = append(result,
+		slog.Any(f.Key, err),
+		slog.Any(f.Key+logr.ErrorChainSuffix, logr.ErrorChain(err)),
+	)
+	if verbose := logr.ErrorVerbose(err); verbose != "" {
+		result = append(result, slog.String(f.Key+logr.ErrorVerboseSuffix, verbose))
+	}
+	return result
+}
+
+// buildAttr maps f to its native slog.Attr. A value that does not match its
+// FieldType (e.g. a Field built by hand) falls back to slog.Any instead of
+// panicking.
 func buildAttr(f logr.Field) slog.Attr {
 	switch f.Type {
 	case logr.StringType:
-		return slog.String(f.Key, f.Value.(string))
+		if v, ok := f.Value.(string); ok {
+			return slog.String(f.Key, v)
+		}
 	case logr.BoolType:
-		return slog.Bool(f.Key, f.Value.(bool))
+		if v, ok := f.Value.(bool); ok {
+			return slog.Bool(f.Key, v)
+		}
 	case logr.IntType:
-		return slog.Int(f.Key, f.Value.(int))
+		if v, ok := f.Value.(int); ok {
+			return slog.Int(f.Key, v)
+		}
+	case logr.Int64Type:
+		if v, ok := f.Value.(int64); ok {
+			return slog.Int64(f.Key, v)
+		}
+	case logr.Int32Type:
+		if v, ok := f.Value.(int32); ok {
+			return slog.Int64(f.Key, int64(v))
+		}
+	case logr.UintType:
+		if v, ok := f.Value.(uint); ok {
+			return slog.Uint64(f.Key, uint64(v))
+		}
+	case logr.Uint32Type:
+		if v, ok := f.Value.(uint32); ok {
+			return slog.Uint64(f.Key, uint64(v))
+		}
 	case logr.Uint64Type:
-		return slog.Uint64(f.Key, f.Value.(uint64))
+		if v, ok := f.Value.(uint64); ok {
+			return slog.Uint64(f.Key, v)
+		}
+	case logr.Float32Type:
+		if v, ok := f.Value.(float32); ok {
+			// Converte pelo texto para não expor a imprecisão do float32 (0.1 -> 0.10000000149011612)
+			v64, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+			return slog.Float64(f.Key, v64)
+		}
 	case logr.Float64Type:
-		return slog.Float64(f.Key, f.Value.(float64))
+		if v, ok := f.Value.(float64); ok {
+			return slog.Float64(f.Key, v)
+		}
 	case logr.TimeType:
-		return slog.Time(f.Key, f.Value.(time.Time))
+		if v, ok := f.Value.(time.Time); ok {
+			return slog.Time(f.Key, v)
+		}
 	case logr.DurationType:
-		return slog.Duration(f.Key, f.Value.(time.Duration))
+		if v, ok := f.Value.(time.Duration); ok {
+			return slog.Duration(f.Key, v)
+		}
+	case logr.BytesType:
+		if v, ok := f.Value.([]byte); ok {
+			return slog.String(f.Key, base64.StdEncoding.EncodeToString(v))
+		}
+	case logr.HexType:
+		if v, ok := f.Value.([]byte); ok {
+			return slog.String(f.Key, hex.EncodeToString(v))
+		}
+	case logr.StringerType:
+		if v, ok := f.Value.(fmt.Stringer); ok {
+			return slog.String(f.Key, fmt.Sprint(v))
+		}
 	case logr.GroupType:
-		groupFields := f.Value.([]logr.Field)
-		return slog.Group(f.Key, buildAttrGroup(groupFields)...)
-	default:
-		return slog.Attr{}
+		if groupFields, ok := f.Value.([]logr.Field); ok {
+			return slog.Group(f.Key, buildAttrGroup(groupFields)...)
+		}
+	case logr.ObjectType:
+		if v, ok := f.Value.(logr.ObjectMarshaler); ok {
+			return slog.Any(f.Key, objectValuer{object: v})
+		}
+	case logr.SecretType:
+		return slog.String(f.Key, logr.RedactedValue)
+	case logr.StringsType, logr.IntsType, logr.AnyType, logr.ErrorType, logr.LazyType, logr.LazyGroupType:
+		// slog.Any já é a codificação nativa desses tipos
 	}
+	return slog.Any(f.Key, f.Value)
 }
 
+// buildAttrs redacts fields and maps them to attrs. Nested fields are
+// redacted along with their group.
 func buildAttrs(fields logr.Fields) []any {
 	result := make([]any, 0, len(fields))
-	for _, f := range fields {
-		result = append(result, buildAttr(f))
+	for _, f := range logr.RedactFields(fields) {
+		result = appendAttr(result, f)
 	}
 	return result
 }
@@ -0,0 +1,8 @@
+module github.com/BrunoTulio/logr/adapters/slog.v1
+
+go 1.22.10
+
+require (
+	github.com/BrunoTulio/logr v0.1.0
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
+)
@@ -2,19 +2,104 @@ package slog
 
 import (
 	"log/slog"
+
+	"github.com/BrunoTulio/logr"
+)
+
+const (
+	sinkConsole = "console"
+	sinkFile    = "file"
 )
 
-func buildLevel(level string) slog.Level {
+// leveler exposes a logr.AtomicLevel as a slog.Leveler, so handlers see level
+// changes on the next record.
+type leveler struct {
+	level *logr.AtomicLevel
+}
+
+func (l leveler) Level() slog.Level {
+	return toSlogLevel(l.level.Level())
+}
+
+// buildLevel returns the level named level, or INFO when it is empty or
+// invalid.
+func buildLevel(level string) logr.Level {
+	if parsed, err := logr.ParseLevel(level); err == nil {
+		return parsed
+	}
+	return logr.LevelInfo
+}
+
+// buildFormat returns the format named format, or TEXT when it is empty or
+// invalid.
+func buildFormat(format string) logr.Format {
+	if parsed, err := logr.ParseFormat(format); err == nil {
+		return parsed
+	}
+	return logr.FormatText
+}
+
+// Níveis do logr que o slog não tem, no mesmo espaçamento dos nativos
+const (
+	slogLevelTrace = slog.LevelDebug - 4
+	slogLevelPanic = slog.LevelError + 4
+	slogLevelFatal = slog.LevelError + 8
+)
+
+func toSlogLevel(level logr.Level) slog.Level {
 	switch level {
-	case "DEBUG":
+	case logr.LevelTrace:
+		return slogLevelTrace
+	case logr.LevelDebug:
 		return slog.LevelDebug
-	case "INFO":
+	case logr.LevelInfo:
 		return slog.LevelInfo
-	case "WARN":
+	case logr.LevelWarn:
 		return slog.LevelWarn
-	case "ERROR":
+	case logr.LevelError:
 		return slog.LevelError
+	case logr.LevelPanic:
+		return slogLevelPanic
+	case logr.LevelFatal:
+		return slogLevelFatal
 	default:
 		return slog.LevelInfo
 	}
 }
+
+// fromSlogLevel is the inverse of toSlogLevel; levels in between round down.
+func fromSlogLevel(level slog.Level) logr.Level {
+	switch {
+	case level >= slogLevelFatal:
+		return logr.LevelFatal
+	case level >= slogLevelPanic:
+		return logr.LevelPanic
+	case level >= slog.LevelError:
+		return logr.LevelError
+	case level >= slog.LevelWarn:
+		return logr.LevelWarn
+	case level >= slog.LevelInfo:
+		return logr.LevelInfo
+	case level >= slog.LevelDebug:
+		return logr.LevelDebug
+	default:
+		return logr.LevelTrace
+	}
+}
+
+// replaceLevel writes the levels slog does not know by their logr names, as
+// in "TRACE" instead of "DEBUG-4".
+func replaceLevel(groups []string, a slog.Attr) slog.Attr {
+	if a.Key != slog.LevelKey || len(groups) > 0 {
+		return a
+	}
+	switch level, _ := a.Value.Any().(slog.Level); level {
+	case slogLevelTrace:
+		a.Value = slog.StringValue(logr.LevelTrace.String())
+	case slogLevelPanic:
+		a.Value = slog.StringValue(logr.LevelPanic.String())
+	case slogLevelFatal:
+		a.Value = slog.StringValue(logr.LevelFatal.String())
+	}
+	return a
+}
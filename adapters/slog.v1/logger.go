@@ -2,86 +2,247 @@ package slog
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
-	"os"
 	"path"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
 )
 
 type ctxKey struct{}
 
-var _ logr.Logger = (*logger)(nil)
+var (
+	_ logr.Logger      = (*logger)(nil)
+	_ logr.DropCounter = (*logger)(nil)
+)
 
-type logger struct {
-	logger *slog.Logger
-	writer io.Writer
-	fields logr.Fields
-	option *Option
-}
+type (
+	logger struct {
+		// root is shared by every logger derived from the same New and is
+		// replaced by Reload.
+		root   *atomic.Pointer[backend]
+		bound  atomic.Pointer[bound]
+		fields logr.Fields
+		lazy   logr.Fields
+	}
+
+	// backend is what Reload rebuilds from a new config.
+	backend struct {
+		logger  *slog.Logger
+		writer  io.Writer
+		writers []io.Writer
+		byName  map[string]config.Sink
+		option  *Option
+		// sampler is nil unless WithSampling was given.
+		sampler *logr.Sampler
+	}
+
+	// bound is the backend logger extended with the eager fields of one
+	// logger.
+	bound struct {
+		backend *backend
+		logger  *slog.Logger
+	}
+)
 
 // Info implements logger.Logger.
 func (l *logger) Info(message string) {
-	l.logger.Info(message)
+	l.write(context.Background(), logr.LevelInfo, message)
 }
 
 // Infof implements logger.Logger.
 func (l *logger) Infof(format string, args ...interface{}) {
-	l.logger.Info(format, args...)
+	if l.Enabled(logr.LevelInfo) {
+		l.write(context.Background(), logr.LevelInfo, fmt.Sprintf(format, args...))
+	}
+}
+
+// InfoContext implements logger.Logger.
+func (l *logger) InfoContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelInfo) {
+		l.write(ctx, logr.LevelInfo, message, buildAttrs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// InfofContext implements logger.Logger.
+func (l *logger) InfofContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelInfo) {
+		l.write(ctx, logr.LevelInfo, fmt.Sprintf(format, args...), buildAttrs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// Infow implements logger.Logger.
+func (l *logger) Infow(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelInfo) {
+		l.write(context.Background(), logr.LevelInfo, message, buildAttrs(logr.Resolve(fields))...)
+	}
 }
 
 // Warn implements logger.Logger.
 func (l *logger) Warn(message string) {
-	l.logger.Warn(message)
+	l.write(context.Background(), logr.LevelWarn, message)
 }
 
 // Warnf implements logger.Logger.
 func (l *logger) Warnf(format string, args ...interface{}) {
-	l.logger.Warn(format, args...)
+	if l.Enabled(logr.LevelWarn) {
+		l.write(context.Background(), logr.LevelWarn, fmt.Sprintf(format, args...))
+	}
+}
+
+// WarnContext implements logger.Logger.
+func (l *logger) WarnContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelWarn) {
+		l.write(ctx, logr.LevelWarn, message, buildAttrs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// WarnfContext implements logger.Logger.
+func (l *logger) WarnfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelWarn) {
+		l.write(ctx, logr.LevelWarn, fmt.Sprintf(format, args...), buildAttrs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// Warnw implements logger.Logger.
+func (l *logger) Warnw(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelWarn) {
+		l.write(context.Background(), logr.LevelWarn, message, buildAttrs(logr.Resolve(fields))...)
+	}
 }
 
 // Debug implements logger.Logger.
 func (l *logger) Debug(message string) {
-	l.logger.Debug(message)
+	l.write(context.Background(), logr.LevelDebug, message)
 }
 
 // Debugf implements logger.Logger.
 func (l *logger) Debugf(format string, args ...interface{}) {
-	l.logger.Debug(format, args...)
+	if l.Enabled(logr.LevelDebug) {
+		l.write(context.Background(), logr.LevelDebug, fmt.Sprintf(format, args...))
+	}
+}
+
+// DebugContext implements logger.Logger.
+func (l *logger) DebugContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelDebug) {
+		l.write(ctx, logr.LevelDebug, message, buildAttrs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// DebugfContext implements logger.Logger.
+func (l *logger) DebugfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelDebug) {
+		l.write(ctx, logr.LevelDebug, fmt.Sprintf(format, args...), buildAttrs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// Debugw implements logger.Logger.
+func (l *logger) Debugw(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelDebug) {
+		l.write(context.Background(), logr.LevelDebug, message, buildAttrs(logr.Resolve(fields))...)
+	}
 }
 
 // Error implements logger.Logger.
 func (l *logger) Error(message string) {
-	l.logger.Error(message)
+	l.write(context.Background(), logr.LevelError, message)
 }
 
 // Errorf implements logger.Logger.
 func (l *logger) Errorf(format string, args ...interface{}) {
-	l.logger.Error(format, args...)
+	if l.Enabled(logr.LevelError) {
+		l.write(context.Background(), logr.LevelError, fmt.Sprintf(format, args...))
+	}
+}
+
+// ErrorContext implements logger.Logger.
+func (l *logger) ErrorContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelError) {
+		l.write(ctx, logr.LevelError, message, buildAttrs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// ErrorfContext implements logger.Logger.
+func (l *logger) ErrorfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelError) {
+		l.write(ctx, logr.LevelError, fmt.Sprintf(format, args...), buildAttrs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// Errorw implements logger.Logger.
+func (l *logger) Errorw(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelError) {
+		l.write(context.Background(), logr.LevelError, message, buildAttrs(logr.Resolve(fields))...)
+	}
 }
 
 // Fatal implements logger.Logger.
 func (l *logger) Fatal(message string) {
-	l.logger.Error(message)
-	os.Exit(1)
+	l.write(context.Background(), logr.LevelFatal, message)
+	l.root.Load().fatal()
 }
 
 // Fatalf implements logger.Logger.
 func (l *logger) Fatalf(format string, args ...interface{}) {
-	l.logger.Error(format, args...)
-	os.Exit(1)
+	l.write(context.Background(), logr.LevelFatal, fmt.Sprintf(format, args...))
+	l.root.Load().fatal()
+}
+
+// Panic implements logger.Logger.
+func (l *logger) Panic(message string) {
+	// o valor do pânico também sai do logger, então vai mascarado
+	message = logr.MaskMessage(message)
+	l.write(context.Background(), logr.LevelPanic, message)
+	panic(message)
+}
+
+// Panicf implements logger.Logger.
+func (l *logger) Panicf(format string, args ...interface{}) {
+	message := logr.MaskMessage(fmt.Sprintf(format, args...))
+	l.write(context.Background(), logr.LevelPanic, message)
+	panic(message)
+}
+
+// Trace implements logger.Logger.
+func (l *logger) Trace(message string) {
+	l.write(context.Background(), logr.LevelTrace, message)
+}
+
+// Tracef implements logger.Logger.
+func (l *logger) Tracef(format string, args ...interface{}) {
+	if l.Enabled(logr.LevelTrace) {
+		l.write(context.Background(), logr.LevelTrace, fmt.Sprintf(format, args...))
+	}
+}
+
+// Log implements logger.Logger.
+func (l *logger) Log(level logr.Level, message string, fields ...logr.Field) {
+	if level == logr.LevelPanic {
+		message = logr.MaskMessage(message)
+	}
+	if l.Enabled(level) {
+		l.write(context.Background(), level, message, buildAttrs(logr.Resolve(fields))...)
+	}
+	switch level {
+	case logr.LevelPanic:
+		panic(message)
+	case logr.LevelFatal:
+		l.root.Load().fatal()
+	}
 }
 
 // FromContext implements logger.Logger.
 func (l *logger) FromContext(ctx context.Context) logr.Logger {
-	fields, ok := ctx.Value(ctxKey{}).(logr.Fields)
-	if !ok {
-		fields = logr.Fields{}
-	}
-	return l.WithFields(fields...)
+	return l.withContext(ctx)
 }
 
 // GetFields implements logger.Logger.
@@ -89,9 +250,47 @@ func (l *logger) GetFields() logr.Fields {
 	return l.fields
 }
 
+// Level implements logger.Logger.
+func (l *logger) Level() logr.Level {
+	return config.LowestLevel(l.root.Load().byName)
+}
+
+// SetLevel implements logger.Logger.
+func (l *logger) SetLevel(level logr.Level) {
+	config.SetLevel(l.root.Load().byName, level)
+}
+
+// Enabled implements logger.Logger.
+func (l *logger) Enabled(level logr.Level) bool {
+	return config.Enabled(l.root.Load().byName, level)
+}
+
+// SinkLevels returns the level of every enabled sink, keyed by sink name
+// ("console", "file"). Changing a returned level affects every logger derived
+// from l.
+func (l *logger) SinkLevels() map[string]*logr.AtomicLevel {
+	return config.SinkLevels(l.root.Load().byName)
+}
+
+// AsyncDropped implements logr.DropCounter. Only the sinks written through
+// WithAsync are included, and their counts survive Reload.
+func (l *logger) AsyncDropped() map[string]uint64 {
+	return config.Dropped(l.root.Load().byName)
+}
+
 // Output implements logger.Logger.
 func (l *logger) Output() io.Writer {
-	return l.writer
+	return l.root.Load().writer
+}
+
+// Sync implements logger.Logger.
+func (l *logger) Sync() error {
+	return config.Sync(l.root.Load().writers)
+}
+
+// Close implements logger.Logger.
+func (l *logger) Close() error {
+	return l.root.Load().Close()
 }
 
 // ToContext implements logger.Logger.
@@ -106,16 +305,73 @@ func (l *logger) WithField(field logr.Field) logr.Logger {
 
 // WithFields implements logger.Logger.
 func (l *logger) WithFields(fields ...logr.Field) logr.Logger {
-	//nolint:gocritic // appendAssign: necessário criar nova slice para manter imutabilidade
-	newFields := append(l.fields, fields...)
-	args := buildAttrs(newFields)
+	eager, lazy := logr.SplitLazy(fields)
+
+	parent := l.bind()
+	derived := &logger{
+		root:   l.root,
+		fields: slices.Concat(l.fields, fields),
+		lazy:   slices.Concat(l.lazy, lazy),
+	}
+	derived.bound.Store(&bound{backend: parent.backend, logger: parent.logger.With(buildAttrs(eager)...)})
+	return derived
+}
+
+// bind returns the backend logger with the eager fields of l, rebuilding it
+// when a Reload replaced the backend it was bound to.
+func (l *logger) bind() *bound {
+	current := l.root.Load()
+	if b := l.bound.Load(); b != nil && b.backend == current {
+		return b
+	}
+	eager, _ := logr.SplitLazy(l.fields)
+	b := &bound{backend: current, logger: current.logger.With(buildAttrs(eager)...)}
+	l.bound.Store(b)
+	return b
+}
+
+// at returns the backend logger for a record at level, extended with the
+// lazy fields only when that record is going to be written.
+func (l *logger) at(level logr.Level) *slog.Logger {
+	current := l.bind().logger
+	if len(l.lazy) == 0 || !l.Enabled(level) {
+		return current
+	}
+	return current.With(buildAttrs(logr.Resolve(l.lazy))...)
+}
+
+// write logs message at level through the backend logger, with the caller
+// of the logr method as the source of the record.
+func (l *logger) write(ctx context.Context, level logr.Level, message string, args ...any) {
+	logger, slogLevel := l.at(level), toSlogLevel(level)
+	if !logger.Enabled(ctx, slogLevel) {
+		return
+	}
+	var pcs [1]uintptr
+	// Callers <- write <- método de log <- chamador
+	runtime.Callers(3, pcs[:])
+	r := slog.NewRecord(time.Now(), slogLevel, message, pcs[0])
+	r.Add(args...)
+	_ = logger.Handler().Handle(ctx, r)
+}
 
-	return &logger{
-		option: l.option,
-		logger: l.logger.With(args...),
-		writer: l.writer,
-		fields: newFields,
+// withContext returns l extended with the fields stored in ctx by ToContext
+// and those returned by the configured context extractors.
+func (l *logger) withContext(ctx context.Context) *logger {
+	fields := l.contextFields(ctx)
+	if len(fields) == 0 {
+		return l
 	}
+	return l.WithFields(fields...).(*logger)
+}
+
+// contextFields returns the fields stored in ctx by ToContext followed by
+// those returned by the context extractors, without the keys l already
+// carries (see logr.ContextFields). The *Context methods log them with the
+// record, without deriving a logger.
+func (l *logger) contextFields(ctx context.Context) logr.Fields {
+	stored, _ := ctx.Value(ctxKey{}).(logr.Fields)
+	return logr.ContextFields(ctx, stored, l.fields, l.root.Load().option.ContextExtractors)
 }
 
 func New(fns ...FnOption) logr.Logger {
@@ -123,6 +379,16 @@ func New(fns ...FnOption) logr.Logger {
 	return NewWithOption(option)
 }
 
+// NewE is like New, but reports invalid levels and formats instead of
+// falling back to INFO and TEXT.
+func NewE(fns ...FnOption) (logr.Logger, error) {
+	option := options(fns)
+	if err := option.validate(); err != nil {
+		return nil, err
+	}
+	return NewWithOption(option), nil
+}
+
 func NewWithOption(o *Option) logr.Logger {
 	l := newLogger(o)
 	logr.Set(l)
@@ -130,61 +396,118 @@ func NewWithOption(o *Option) logr.Logger {
 }
 
 func newLogger(o *Option, fields ...logr.Field) *logger {
-	handler, writer := buildHandlerAndWrite(o)
 	l := &logger{
-		option: o,
-		logger: slog.New(handler),
-		writer: writer,
+		root:   &atomic.Pointer[backend]{},
 		fields: fields,
 	}
+	l.root.Store(newBackend(o))
+	return l
+}
+
+func newBackend(o *Option) *backend {
+	handler, writers, byName := buildHandlerAndWriters(o)
+	b := &backend{
+		writer:  io.MultiWriter(writers...),
+		writers: writers,
+		byName:  byName,
+		option:  o,
+	}
+	if o.Sampling.Initial > 0 {
+		b.sampler = logr.NewSampler(o.Sampling.Initial, o.Sampling.Thereafter, o.Sampling.Interval)
+		handler = samplingHandler{Handler: handler, sampler: b.sampler}
+	}
+	handler = maskingHandler{Handler: handler}
+	b.logger = slog.New(handler)
+	if b.sampler != nil {
+		b.sampler.Report(b.reporter().reportSampling)
+	}
+	return b
+}
+
+// reporter returns a logger that writes straight to b, for the records the
+// backend logs itself.
+func (b *backend) reporter() *logger {
+	l := &logger{root: &atomic.Pointer[backend]{}}
+	l.root.Store(b)
 	return l
 }
 
-func buildHandlerOption(level string, addSource bool) *slog.HandlerOptions {
+// reportSampling logs how many records the sampler suppressed.
+func (l *logger) reportSampling(suppressed uint64) {
+	l.Warnw(logr.SamplingMessage,
+		logr.Uint64("suppressed", suppressed),
+		logr.Duration("interval", l.root.Load().option.Sampling.Interval),
+	)
+}
+
+// Sinks implements config.Backend.
+func (b *backend) Sinks() map[string]config.Sink {
+	return b.byName
+}
+
+// Close implements config.Backend. It stops the sampler and closes the
+// writers as config.Close does.
+func (b *backend) Close() error {
+	if b.sampler != nil {
+		b.sampler.Stop()
+	}
+	return config.Close[*lumberjack.Logger](b.writers)
+}
+
+// fatal ends Fatal once the record is written; see config.Fatal.
+func (b *backend) fatal() {
+	config.Fatal(b.writers, b.option.OnFatal)
+}
+
+func buildHandlerOption(level *logr.AtomicLevel, addSource bool) *slog.HandlerOptions {
 	return &slog.HandlerOptions{
-		AddSource: addSource,
-		Level:     buildLevel(level),
+		AddSource:   addSource,
+		Level:       leveler{level: level},
+		ReplaceAttr: replaceLevel,
 	}
 }
 
 func buildFormatter(w io.Writer, formatter string, opts *slog.HandlerOptions) slog.Handler {
-	switch formatter {
-	case "JSON":
+	switch buildFormat(formatter) {
+	case logr.FormatJSON:
 		return slog.NewJSONHandler(w, opts)
-	case "TEXT":
-		return slog.NewTextHandler(w, opts)
 	default:
 		return slog.NewTextHandler(w, opts)
 	}
 }
 
-func buildHandlerAndWrite(o *Option) (slog.Handler, io.Writer) {
+func buildHandlerAndWriters(o *Option) (slog.Handler, []io.Writer, map[string]config.Sink) {
 	var handlers []slog.Handler
 	var writers []io.Writer
+	byName := make(map[string]config.Sink)
 
 	if o.Console.Enabled {
-		consoleWriter := os.Stdout
-		consoleHandler := buildFormatter(consoleWriter,
+		consoleWriter := o.async(o.consoleWriter())
+		console := config.NewSink(consoleWriter, buildLevel(o.Console.Level))
+		consoleHandler := buildFormatter(console.Writer,
 			o.Console.Formatter,
-			buildHandlerOption(o.Console.Level, o.AddSource),
+			buildHandlerOption(console.Level, o.AddSource),
 		)
 		handlers = append(handlers, consoleHandler)
 		writers = append(writers, consoleWriter)
+		byName[sinkConsole] = console
 	}
 
 	if o.File.Enabled {
-		fileWriter := &lumberjack.Logger{
+		fileWriter := o.async(&lumberjack.Logger{
 			Filename: path.Join(o.File.Path, o.File.Name),
 			MaxSize:  o.File.MaxSize,
 			MaxAge:   o.File.MaxAge,
 			Compress: o.File.Compress,
-		}
-		consoleHandler := buildFormatter(fileWriter,
+		})
+		file := config.NewSink(fileWriter, buildLevel(o.File.Level))
+		consoleHandler := buildFormatter(file.Writer,
 			o.File.Formatter,
-			buildHandlerOption(o.File.Level, o.AddSource),
+			buildHandlerOption(file.Level, o.AddSource),
 		)
 		handlers = append(handlers, consoleHandler)
 		writers = append(writers, fileWriter)
+		byName[sinkFile] = file
 	}
 
 	if len(handlers) == 0 {
@@ -195,8 +518,7 @@ func buildHandlerAndWrite(o *Option) (slog.Handler, io.Writer) {
 	}
 
 	combinedHandler := NewMultiHandler(handlers...)
-	combinedWriter := io.MultiWriter(writers...)
-	return combinedHandler, combinedWriter
+	return combinedHandler, writers, byName
 }
 
 func options(fns []FnOption) *Option {
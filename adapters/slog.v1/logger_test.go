@@ -0,0 +1,116 @@
+package slog_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/adapters/slog.v1"
+	"github.com/BrunoTulio/logr/logrtest/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, func(w io.Writer, level logr.Level) logr.Logger {
+		l := slog.New(slog.WithConsole(true), slog.WithConsoleWriter(w), slog.WithConsoleFormatter("JSON"))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "ERROR")
+	t.Setenv("LOG_FORMAT", "JSON")
+
+	opts, err := slog.FromEnv("LOG")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	l := slog.New(append(opts, slog.WithConsoleWriter(&buf))...)
+	l.Warn("hidden")
+	l.Error("shown")
+
+	records := conformance.Decode(t, &buf)
+	if len(records) != 1 || records[0].Message() != "shown" {
+		t.Errorf("records = %v", records)
+	}
+
+	t.Setenv("LOG_LEVEL", "debugg")
+	if _, err := slog.FromEnv("LOG"); err == nil || !strings.Contains(err.Error(), `"debugg"`) {
+		t.Errorf("FromEnv error = %v", err)
+	}
+}
+
+func TestFatal(t *testing.T) {
+	conformance.RunFatal(t, func(w io.Writer, onFatal func()) logr.Logger {
+		return slog.New(slog.WithConsole(true), slog.WithConsoleWriter(w), slog.WithConsoleFormatter("JSON"), slog.WithOnFatal(onFatal))
+	})
+}
+
+func TestSampling(t *testing.T) {
+	conformance.RunSampling(t, func(w io.Writer, initial, thereafter int, interval time.Duration) logr.Logger {
+		return slog.New(slog.WithConsole(true), slog.WithConsoleWriter(w), slog.WithConsoleFormatter("JSON"), slog.WithSampling(initial, thereafter, interval))
+	})
+}
+
+func TestContextExtractors(t *testing.T) {
+	conformance.RunContextExtractors(t, func(w io.Writer, level logr.Level, extractor logr.ContextExtractor) logr.Logger {
+		l := slog.New(slog.WithConsole(true), slog.WithConsoleWriter(w), slog.WithConsoleFormatter("JSON"), slog.WithContextExtractor(extractor))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestCaller(t *testing.T) {
+	conformance.RunCaller(t, func(w io.Writer) logr.Logger {
+		return slog.New(slog.WithConsole(true), slog.WithConsoleWriter(w), slog.WithConsoleFormatter("JSON"), slog.WithAddSource(true))
+	})
+}
+
+func TestSinkLevels(t *testing.T) {
+	var console bytes.Buffer
+	dir := t.TempDir()
+	l := slog.New(
+		slog.WithConsole(true), slog.WithConsoleWriter(&console), slog.WithConsoleFormatter("JSON"), slog.WithConsoleLevel(logr.LevelWarn),
+		slog.WithFile(true, dir, "app.log"), slog.WithFileFormatter("JSON"), slog.WithFileLevel(logr.LevelDebug),
+	)
+	levels := l.(interface {
+		SinkLevels() map[string]*logr.AtomicLevel
+	}).SinkLevels()
+
+	l.Info("file only")
+	levels["console"].SetLevel(logr.LevelInfo)
+	l.Info("both")
+	l.SetLevel(logr.LevelError)
+	l.Warn("none")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for sink, tt := range map[string]struct {
+		r    io.Reader
+		want []string
+	}{
+		"console": {&console, []string{"both"}},
+		"file":    {file, []string{"file only", "both"}},
+	} {
+		var got []string
+		for _, r := range conformance.Decode(t, tt.r) {
+			got = append(got, r.Message())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s got %q, want %q", sink, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/BrunoTulio/logr"
+)
+
+// maskingHandler masks the message with logr.MaskMessage before the record
+// reaches the sinks. Attrs are masked when they are built.
+type maskingHandler struct {
+	slog.Handler
+}
+
+func (h maskingHandler) Handle(ctx context.Context, rec slog.Record) error {
+	rec.Message = logr.MaskMessage(rec.Message)
+	return h.Handler.Handle(ctx, rec)
+}
+
+func (h maskingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return maskingHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h maskingHandler) WithGroup(name string) slog.Handler {
+	return maskingHandler{Handler: h.Handler.WithGroup(name)}
+}
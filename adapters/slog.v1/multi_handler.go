@@ -16,7 +16,12 @@ func NewMultiHandler(handlers ...slog.Handler) slog.Handler {
 }
 
 func (m *MultiHandler) Enabled(ctx context.Context, level slog.Level) bool {
-	return true
+	for _, h := range m.handlers {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *MultiHandler) Handle(ctx context.Context, rec slog.Record) error {
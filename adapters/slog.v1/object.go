@@ -0,0 +1,77 @@
+package slog
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+)
+
+var (
+	_ slog.LogValuer     = objectValuer{}
+	_ logr.ObjectEncoder = (*objectEncoder)(nil)
+)
+
+// objectValuer exposes a logr.ObjectMarshaler as a slog.LogValuer, rendered
+// as a group.
+type objectValuer struct {
+	object logr.ObjectMarshaler
+}
+
+func (v objectValuer) LogValue() slog.Value {
+	enc := &objectEncoder{}
+	if err := v.object.MarshalLogObject(enc); err != nil {
+		enc.AddString(logr.ErrorKey, err.Error())
+	}
+	return slog.GroupValue(enc.attrs...)
+}
+
+// objectEncoder collects the fields of a logr.ObjectMarshaler as slog attrs.
+type objectEncoder struct {
+	attrs []slog.Attr
+}
+
+func (e *objectEncoder) AddString(key, value string) {
+	e.attrs = append(e.attrs, slog.String(key, value))
+}
+
+func (e *objectEncoder) AddBool(key string, value bool) {
+	e.attrs = append(e.attrs, slog.Bool(key, value))
+}
+
+func (e *objectEncoder) AddInt(key string, value int) {
+	e.attrs = append(e.attrs, slog.Int(key, value))
+}
+
+func (e *objectEncoder) AddInt64(key string, value int64) {
+	e.attrs = append(e.attrs, slog.Int64(key, value))
+}
+
+func (e *objectEncoder) AddUint64(key string, value uint64) {
+	e.attrs = append(e.attrs, slog.Uint64(key, value))
+}
+
+func (e *objectEncoder) AddFloat64(key string, value float64) {
+	e.attrs = append(e.attrs, slog.Float64(key, value))
+}
+
+func (e *objectEncoder) AddTime(key string, value time.Time) {
+	e.attrs = append(e.attrs, slog.Time(key, value))
+}
+
+func (e *objectEncoder) AddDuration(key string, value time.Duration) {
+	e.attrs = append(e.attrs, slog.Duration(key, value))
+}
+
+func (e *objectEncoder) AddStrings(key string, value []string) {
+	e.attrs = append(e.attrs, slog.Any(key, value))
+}
+
+func (e *objectEncoder) AddObject(key string, value logr.ObjectMarshaler) error {
+	e.attrs = append(e.attrs, slog.Any(key, objectValuer{object: value}))
+	return nil
+}
+
+func (e *objectEncoder) AddAny(key string, value any) {
+	e.attrs = append(e.attrs, slog.Any(key, value))
+}
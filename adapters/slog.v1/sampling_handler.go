@@ -0,0 +1,30 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/BrunoTulio/logr"
+)
+
+// samplingHandler drops the records its sampler does not keep before they
+// reach the sinks.
+type samplingHandler struct {
+	slog.Handler
+	sampler *logr.Sampler
+}
+
+func (h samplingHandler) Handle(ctx context.Context, rec slog.Record) error {
+	if !h.sampler.Sample(fromSlogLevel(rec.Level), rec.Message) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, rec)
+}
+
+func (h samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return samplingHandler{Handler: h.Handler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h samplingHandler) WithGroup(name string) slog.Handler {
+	return samplingHandler{Handler: h.Handler.WithGroup(name), sampler: h.sampler}
+}
@@ -3,16 +3,28 @@ package zap
 import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/BrunoTulio/logr"
 )
 
 func buildEncoder(format string) zapcore.Encoder {
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoderConfig.EncodeLevel = encodeLevel
 
-	switch format {
-	case "JSON":
+	switch buildFormat(format) {
+	case logr.FormatJSON:
 		return zapcore.NewJSONEncoder(encoderConfig)
 	default:
 		return zapcore.NewConsoleEncoder(encoderConfig)
 	}
 }
+
+// encodeLevel is zapcore.LowercaseLevelEncoder, also naming zapLevelTrace.
+func encodeLevel(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if level == zapLevelTrace {
+		enc.AppendString("trace")
+		return
+	}
+	zapcore.LowercaseLevelEncoder(level, enc)
+}
@@ -1,33 +1,135 @@
 package zap
 
 import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
 	"github.com/BrunoTulio/logr"
 )
 
-const fieldsValuePair = 2
-
+// buildSugaredArgs redacts fields and maps them to zap fields. Nested fields
+// are redacted along with their group.
 func buildSugaredArgs(fields logr.Fields) []interface{} {
-	args := make([]interface{}, 0, len(fields)*fieldsValuePair)
-	for _, f := range fields {
-		if f.Type == logr.GroupType {
-			groupFields := f.Value.([]logr.Field)
-			args = append(args, f.Key, buildGroupMap(groupFields))
-		} else {
-			args = append(args, f.Key, f.Value)
-		}
+	zapFields := buildFields(logr.RedactFields(fields))
+	args := make([]interface{}, 0, len(zapFields))
+	for _, f := range zapFields {
+		args = append(args, f)
 	}
 	return args
 }
 
-func buildGroupMap(fields logr.Fields) map[string]interface{} {
-	m := make(map[string]interface{}, len(fields))
+func buildFields(fields logr.Fields) []zap.Field {
+	result := make([]zap.Field, 0, len(fields))
 	for _, f := range fields {
-		switch f.Type {
-		case logr.GroupType:
-			m[f.Key] = buildGroupMap(f.Value.([]logr.Field))
-		case logr.StringType, logr.BoolType, logr.IntType, logr.Uint64Type, logr.Float64Type, logr.TimeType, logr.DurationType:
-			m[f.Key] = f.Value
+		if f.Type == logr.ErrorType {
+			result = appendErrorFields(result, f)
+			continue
+		}
+		result = append(result, buildField(f))
+	}
+	return result
+}
+
+func appendErrorFields(result []zap.Field, f logr.Field) []zap.Field {
+	err, _ := f.Value.(error)
+	if err == nil {
+		return result
+	}
+	// zap.NamedError já adiciona o campo ${key}Verbose quando disponível
+	return append(result,
+		zap.NamedError(f.Key, err),
+		zap.Any(f.Key+logr.ErrorChainSuffix, logr.ErrorChain(err)),
+	)
+}
+
+// buildField maps f to its native zap.Field. A value that does not match its
+// FieldType (e.g. a Field built by hand) falls back to zap.Any instead of
+// panicking.
+func buildField(f logr.Field) zap.Field {
+	switch f.Type {
+	case logr.StringType:
+		if v, ok := f.Value.(string); ok {
+			return zap.String(f.Key, v)
+		}
+	case logr.BoolType:
+		if v, ok := f.Value.(bool); ok {
+			return zap.Bool(f.Key, v)
+		}
+	case logr.IntType:
+		if v, ok := f.Value.(int); ok {
+			return zap.Int(f.Key, v)
+		}
+	case logr.Int64Type:
+		if v, ok := f.Value.(int64); ok {
+			return zap.Int64(f.Key, v)
+		}
+	case logr.Int32Type:
+		if v, ok := f.Value.(int32); ok {
+			return zap.Int32(f.Key, v)
+		}
+	case logr.UintType:
+		if v, ok := f.Value.(uint); ok {
+			return zap.Uint(f.Key, v)
+		}
+	case logr.Uint32Type:
+		if v, ok := f.Value.(uint32); ok {
+			return zap.Uint32(f.Key, v)
+		}
+	case logr.Uint64Type:
+		if v, ok := f.Value.(uint64); ok {
+			return zap.Uint64(f.Key, v)
+		}
+	case logr.Float32Type:
+		if v, ok := f.Value.(float32); ok {
+			return zap.Float32(f.Key, v)
+		}
+	case logr.Float64Type:
+		if v, ok := f.Value.(float64); ok {
+			return zap.Float64(f.Key, v)
+		}
+	case logr.TimeType:
+		if v, ok := f.Value.(time.Time); ok {
+			return zap.Time(f.Key, v)
+		}
+	case logr.DurationType:
+		if v, ok := f.Value.(time.Duration); ok {
+			return zap.Duration(f.Key, v)
+		}
+	case logr.BytesType:
+		if v, ok := f.Value.([]byte); ok {
+			return zap.Binary(f.Key, v)
+		}
+	case logr.HexType:
+		if v, ok := f.Value.([]byte); ok {
+			return zap.String(f.Key, hex.EncodeToString(v))
+		}
+	case logr.StringsType:
+		if v, ok := f.Value.([]string); ok {
+			return zap.Strings(f.Key, v)
+		}
+	case logr.IntsType:
+		if v, ok := f.Value.([]int); ok {
+			return zap.Ints(f.Key, v)
+		}
+	case logr.StringerType:
+		if v, ok := f.Value.(fmt.Stringer); ok {
+			return zap.Stringer(f.Key, v)
+		}
+	case logr.GroupType:
+		if groupFields, ok := f.Value.([]logr.Field); ok {
+			return zap.Dict(f.Key, buildFields(groupFields)...)
+		}
+	case logr.ObjectType:
+		if v, ok := f.Value.(logr.ObjectMarshaler); ok {
+			return zap.Object(f.Key, objectMarshaler{object: v})
 		}
+	case logr.SecretType:
+		return zap.String(f.Key, logr.RedactedValue)
+	case logr.AnyType, logr.ErrorType, logr.LazyType, logr.LazyGroupType:
+		// zap.Any já é a codificação nativa desses tipos
 	}
-	return m
+	return zap.Any(f.Key, f.Value)
 }
@@ -0,0 +1,11 @@
+module github.com/BrunoTulio/logr/adapters/zap.v1
+
+go 1.22.10
+
+require (
+	github.com/BrunoTulio/logr v0.1.0
+	go.uber.org/zap v1.27.0
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
+)
+
+require go.uber.org/multierr v1.11.0 // indirect
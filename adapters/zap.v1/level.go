@@ -3,19 +3,85 @@ package zap
 import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/BrunoTulio/logr"
+)
+
+const (
+	sinkConsole = "console"
+	sinkFile    = "file"
 )
 
-func buildLevel(level string) zapcore.Level {
+// levelEnabler exposes a logr.AtomicLevel as a zapcore.LevelEnabler, so cores
+// see level changes on the next entry.
+type levelEnabler struct {
+	level *logr.AtomicLevel
+}
+
+func (e levelEnabler) Enabled(level zapcore.Level) bool {
+	return level >= toZapLevel(e.level.Level())
+}
+
+// buildLevel returns the level named level, or INFO when it is empty or
+// invalid.
+func buildLevel(level string) logr.Level {
+	if parsed, err := logr.ParseLevel(level); err == nil {
+		return parsed
+	}
+	return logr.LevelInfo
+}
+
+// buildFormat returns the format named format, or TEXT when it is empty or
+// invalid.
+func buildFormat(format string) logr.Format {
+	if parsed, err := logr.ParseFormat(format); err == nil {
+		return parsed
+	}
+	return logr.FormatText
+}
+
+// zapLevelTrace is the logr level zap does not have, written as "trace" by
+// encodeLevel.
+const zapLevelTrace = zap.DebugLevel - 1
+
+func toZapLevel(level logr.Level) zapcore.Level {
 	switch level {
-	case "DEBUG":
+	case logr.LevelTrace:
+		return zapLevelTrace
+	case logr.LevelDebug:
 		return zap.DebugLevel
-	case "INFO":
+	case logr.LevelInfo:
 		return zap.InfoLevel
-	case "WARN":
+	case logr.LevelWarn:
 		return zap.WarnLevel
-	case "ERROR":
+	case logr.LevelError:
 		return zap.ErrorLevel
+	case logr.LevelPanic:
+		return zap.PanicLevel
+	case logr.LevelFatal:
+		return zap.FatalLevel
 	default:
 		return zap.InfoLevel
 	}
 }
+
+func fromZapLevel(level zapcore.Level) logr.Level {
+	switch level {
+	case zapLevelTrace:
+		return logr.LevelTrace
+	case zap.DebugLevel:
+		return logr.LevelDebug
+	case zap.InfoLevel:
+		return logr.LevelInfo
+	case zap.WarnLevel:
+		return logr.LevelWarn
+	case zap.ErrorLevel:
+		return logr.LevelError
+	case zap.PanicLevel, zap.DPanicLevel:
+		return logr.LevelPanic
+	case zap.FatalLevel:
+		return logr.LevelFatal
+	default:
+		return logr.LevelInfo
+	}
+}
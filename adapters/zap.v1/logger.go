@@ -2,67 +2,168 @@ package zap
 
 import (
 	"context"
+	"fmt"
 	"io"
-	"os"
 	"path"
+	"slices"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
 )
 
 type ctxKey struct{}
 
 const callerSkip = 1
 
-var _ logr.Logger = (*logger)(nil)
+var (
+	_ logr.Logger      = (*logger)(nil)
+	_ logr.DropCounter = (*logger)(nil)
+)
 
-type logger struct {
-	logger *zap.SugaredLogger
-	writer io.Writer
-	fields logr.Fields
-	option *Option
-}
+type (
+	logger struct {
+		// root is shared by every logger derived from the same New and is
+		// replaced by Reload.
+		root   *atomic.Pointer[backend]
+		bound  atomic.Pointer[bound]
+		fields logr.Fields
+		lazy   logr.Fields
+	}
+
+	// backend is what Reload rebuilds from a new config.
+	backend struct {
+		logger  *zap.SugaredLogger
+		writer  io.Writer
+		writers []io.Writer
+		byName  map[string]config.Sink
+		option  *Option
+		// sampler is nil unless WithSampling was given.
+		sampler *logr.Sampler
+	}
+
+	// bound is the backend logger extended with the eager fields of one
+	// logger.
+	bound struct {
+		backend *backend
+		logger  *zap.SugaredLogger
+	}
+)
 
 // Debug implements logr.Logger.
 func (l *logger) Debug(message string) {
-	l.logger.Debug(message)
+	l.at(logr.LevelDebug).Debug(message)
 }
 
 // Debugf implements logr.Logger.
 func (l *logger) Debugf(format string, args ...interface{}) {
-	l.logger.Debugf(format, args...)
+	if l.Enabled(logr.LevelDebug) {
+		l.at(logr.LevelDebug).Debug(fmt.Sprintf(format, args...))
+	}
+}
+
+// DebugContext implements logr.Logger.
+func (l *logger) DebugContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelDebug) {
+		l.at(logr.LevelDebug).Debugw(message, buildSugaredArgs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// DebugfContext implements logr.Logger.
+func (l *logger) DebugfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelDebug) {
+		l.at(logr.LevelDebug).Debugw(fmt.Sprintf(format, args...), buildSugaredArgs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// Debugw implements logr.Logger.
+func (l *logger) Debugw(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelDebug) {
+		l.at(logr.LevelDebug).Debugw(message, buildSugaredArgs(logr.Resolve(fields))...)
+	}
 }
 
 // Error implements logr.Logger.
 func (l *logger) Error(message string) {
-	l.logger.Error(message)
+	l.at(logr.LevelError).Error(message)
 }
 
 // Errorf implements logr.Logger.
 func (l *logger) Errorf(format string, args ...interface{}) {
-	l.logger.Errorf(format, args...)
+	if l.Enabled(logr.LevelError) {
+		l.at(logr.LevelError).Error(fmt.Sprintf(format, args...))
+	}
+}
+
+// ErrorContext implements logr.Logger.
+func (l *logger) ErrorContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelError) {
+		l.at(logr.LevelError).Errorw(message, buildSugaredArgs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// ErrorfContext implements logr.Logger.
+func (l *logger) ErrorfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelError) {
+		l.at(logr.LevelError).Errorw(fmt.Sprintf(format, args...), buildSugaredArgs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// Errorw implements logr.Logger.
+func (l *logger) Errorw(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelError) {
+		l.at(logr.LevelError).Errorw(message, buildSugaredArgs(logr.Resolve(fields))...)
+	}
 }
 
 // Fatal implements logr.Logger.
 func (l *logger) Fatal(message string) {
-	l.logger.Fatal(message)
+	l.at(logr.LevelFatal).Fatal(message)
 }
 
 // Fatalf implements logr.Logger.
 func (l *logger) Fatalf(format string, args ...interface{}) {
-	l.logger.Fatalf(format, args...)
+	l.at(logr.LevelFatal).Fatal(fmt.Sprintf(format, args...))
+}
+
+// Panic implements logr.Logger.
+func (l *logger) Panic(message string) {
+	l.at(logr.LevelPanic).Panic(message)
+}
+
+// Panicf implements logr.Logger.
+func (l *logger) Panicf(format string, args ...interface{}) {
+	l.at(logr.LevelPanic).Panic(fmt.Sprintf(format, args...))
+}
+
+// Trace implements logr.Logger.
+func (l *logger) Trace(message string) {
+	l.at(logr.LevelTrace).Log(zapLevelTrace, message)
+}
+
+// Tracef implements logr.Logger.
+func (l *logger) Tracef(format string, args ...interface{}) {
+	if l.Enabled(logr.LevelTrace) {
+		l.at(logr.LevelTrace).Log(zapLevelTrace, fmt.Sprintf(format, args...))
+	}
+}
+
+// Log implements logr.Logger.
+func (l *logger) Log(level logr.Level, message string, fields ...logr.Field) {
+	// o zap entra em pânico ou chama o fatalHook nesses níveis mesmo quando
+	// nenhum sink os aceita
+	if l.Enabled(level) || level >= logr.LevelPanic {
+		l.at(level).Logw(toZapLevel(level), message, buildSugaredArgs(logr.Resolve(fields))...)
+	}
 }
 
 // FromContext implements logr.Logger.
 func (l *logger) FromContext(ctx context.Context) logr.Logger {
-	fields, ok := ctx.Value(ctxKey{}).(logr.Fields)
-	if !ok {
-		fields = logr.Fields{}
-	}
-	return l.WithFields(fields...)
+	return l.withContext(ctx)
 }
 
 // GetFields implements logr.Logger.
@@ -72,27 +173,111 @@ func (l *logger) GetFields() logr.Fields {
 
 // Info implements logr.Logger.
 func (l *logger) Info(message string) {
-	l.logger.Info(message)
+	l.at(logr.LevelInfo).Info(message)
 }
 
 // Infof implements logr.Logger.
 func (l *logger) Infof(format string, args ...interface{}) {
-	l.logger.Infof(format, args...)
+	if l.Enabled(logr.LevelInfo) {
+		l.at(logr.LevelInfo).Info(fmt.Sprintf(format, args...))
+	}
+}
+
+// InfoContext implements logr.Logger.
+func (l *logger) InfoContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelInfo) {
+		l.at(logr.LevelInfo).Infow(message, buildSugaredArgs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// InfofContext implements logr.Logger.
+func (l *logger) InfofContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelInfo) {
+		l.at(logr.LevelInfo).Infow(fmt.Sprintf(format, args...), buildSugaredArgs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// Infow implements logr.Logger.
+func (l *logger) Infow(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelInfo) {
+		l.at(logr.LevelInfo).Infow(message, buildSugaredArgs(logr.Resolve(fields))...)
+	}
 }
 
 // Warn implements logr.Logger.
 func (l *logger) Warn(message string) {
-	l.logger.Warn(message)
+	l.at(logr.LevelWarn).Warn(message)
 }
 
 // Warnf implements logr.Logger.
 func (l *logger) Warnf(format string, args ...interface{}) {
-	l.logger.Warnf(format, args...)
+	if l.Enabled(logr.LevelWarn) {
+		l.at(logr.LevelWarn).Warn(fmt.Sprintf(format, args...))
+	}
+}
+
+// WarnContext implements logr.Logger.
+func (l *logger) WarnContext(ctx context.Context, message string) {
+	if l.Enabled(logr.LevelWarn) {
+		l.at(logr.LevelWarn).Warnw(message, buildSugaredArgs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// WarnfContext implements logr.Logger.
+func (l *logger) WarnfContext(ctx context.Context, format string, args ...interface{}) {
+	if l.Enabled(logr.LevelWarn) {
+		l.at(logr.LevelWarn).Warnw(fmt.Sprintf(format, args...), buildSugaredArgs(logr.Resolve(l.contextFields(ctx)))...)
+	}
+}
+
+// Warnw implements logr.Logger.
+func (l *logger) Warnw(message string, fields ...logr.Field) {
+	if l.Enabled(logr.LevelWarn) {
+		l.at(logr.LevelWarn).Warnw(message, buildSugaredArgs(logr.Resolve(fields))...)
+	}
+}
+
+// Level implements logr.Logger.
+func (l *logger) Level() logr.Level {
+	return config.LowestLevel(l.root.Load().byName)
+}
+
+// SetLevel implements logr.Logger.
+func (l *logger) SetLevel(level logr.Level) {
+	config.SetLevel(l.root.Load().byName, level)
+}
+
+// Enabled implements logr.Logger.
+func (l *logger) Enabled(level logr.Level) bool {
+	return config.Enabled(l.root.Load().byName, level)
+}
+
+// SinkLevels returns the level of every enabled sink, keyed by sink name
+// ("console", "file"). Changing a returned level affects every logger derived
+// from l.
+func (l *logger) SinkLevels() map[string]*logr.AtomicLevel {
+	return config.SinkLevels(l.root.Load().byName)
+}
+
+// AsyncDropped implements logr.DropCounter. Only the sinks written through
+// WithAsync are included, and their counts survive Reload.
+func (l *logger) AsyncDropped() map[string]uint64 {
+	return config.Dropped(l.root.Load().byName)
 }
 
 // Output implements logr.Logger.
 func (l *logger) Output() io.Writer {
-	return l.writer
+	return l.root.Load().writer
+}
+
+// Sync implements logr.Logger.
+func (l *logger) Sync() error {
+	return config.Sync(l.root.Load().writers)
+}
+
+// Close implements logr.Logger.
+func (l *logger) Close() error {
+	return l.root.Load().Close()
 }
 
 // ToContext implements logr.Logger.
@@ -107,16 +292,58 @@ func (l *logger) WithField(field logr.Field) logr.Logger {
 
 // WithFields implements logr.Logger.
 func (l *logger) WithFields(fields ...logr.Field) logr.Logger {
-	//nolint:gocritic // appendAssign: necessário criar nova slice para manter imutabilidade
-	newFields := append(l.fields, fields...)
-	args := buildSugaredArgs(newFields)
+	eager, lazy := logr.SplitLazy(fields)
+
+	parent := l.bind()
+	derived := &logger{
+		root:   l.root,
+		fields: slices.Concat(l.fields, fields),
+		lazy:   slices.Concat(l.lazy, lazy),
+	}
+	derived.bound.Store(&bound{backend: parent.backend, logger: parent.logger.With(buildSugaredArgs(eager)...)})
+	return derived
+}
 
-	return &logger{
-		option: l.option,
-		logger: l.logger.With(args...),
-		writer: l.writer,
-		fields: newFields,
+// bind returns the backend logger with the eager fields of l, rebuilding it
+// when a Reload replaced the backend it was bound to.
+func (l *logger) bind() *bound {
+	current := l.root.Load()
+	if b := l.bound.Load(); b != nil && b.backend == current {
+		return b
 	}
+	eager, _ := logr.SplitLazy(l.fields)
+	b := &bound{backend: current, logger: current.logger.With(buildSugaredArgs(eager)...)}
+	l.bound.Store(b)
+	return b
+}
+
+// at returns the backend logger for a record at level, extended with the
+// lazy fields only when that record is going to be written.
+func (l *logger) at(level logr.Level) *zap.SugaredLogger {
+	current := l.bind().logger
+	if len(l.lazy) == 0 || !l.Enabled(level) {
+		return current
+	}
+	return current.With(buildSugaredArgs(logr.Resolve(l.lazy))...)
+}
+
+// withContext returns l extended with the fields stored in ctx by ToContext
+// and those returned by the configured context extractors.
+func (l *logger) withContext(ctx context.Context) *logger {
+	fields := l.contextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.WithFields(fields...).(*logger)
+}
+
+// contextFields returns the fields stored in ctx by ToContext followed by
+// those returned by the context extractors, without the keys l already
+// carries (see logr.ContextFields). The *Context methods log them with the
+// record, without deriving a logger.
+func (l *logger) contextFields(ctx context.Context) logr.Fields {
+	stored, _ := ctx.Value(ctxKey{}).(logr.Fields)
+	return logr.ContextFields(ctx, stored, l.fields, l.root.Load().option.ContextExtractors)
 }
 
 func New(fns ...FnOption) logr.Logger {
@@ -124,62 +351,137 @@ func New(fns ...FnOption) logr.Logger {
 	return NewWithOption(option)
 }
 
+// NewE is like New, but reports invalid levels and formats instead of
+// falling back to INFO and TEXT.
+func NewE(fns ...FnOption) (logr.Logger, error) {
+	option := options(fns)
+	if err := option.validate(); err != nil {
+		return nil, err
+	}
+	return NewWithOption(option), nil
+}
+
 func NewWithOption(o *Option) logr.Logger {
 	l := newLogger(o)
 	logr.Set(l)
 	return l
 }
 
-func buildCoreAndWriter(o *Option) (zapcore.Core, io.Writer) {
+func buildCoreAndWriters(o *Option) (zapcore.Core, []io.Writer, map[string]config.Sink) {
 	cores := []zapcore.Core{}
 	var writers []io.Writer
+	byName := make(map[string]config.Sink)
 
 	if o.Console.Enabled {
-		level := buildLevel(o.Console.Level)
-		writer := zapcore.Lock(os.Stdout)
-		coreconsole := zapcore.NewCore(buildEncoder(o.Console.Formatter), writer, level)
+		console := o.async(o.consoleWriter())
+		sink := config.NewSink(console, buildLevel(o.Console.Level))
+		writer := zapcore.Lock(zapcore.AddSync(sink.Writer))
+		coreconsole := zapcore.NewCore(buildEncoder(o.Console.Formatter), writer, levelEnabler{level: sink.Level})
 		cores = append(cores, coreconsole)
-		writers = append(writers, writer)
+		writers = append(writers, console)
+		byName[sinkConsole] = sink
 	}
 
 	if o.File.Enabled {
-		lumber := &lumberjack.Logger{
+		lumber := o.async(&lumberjack.Logger{
 			Filename: path.Join(o.File.Path, o.File.Name),
 			MaxSize:  o.File.MaxSize,
 			Compress: o.File.Compress,
 			MaxAge:   o.File.MaxAge,
-		}
+		})
 
-		level := buildLevel(o.File.Level)
-		writer := zapcore.AddSync(lumber)
-		corefile := zapcore.NewCore(buildEncoder(o.File.Formatter), writer, level)
+		sink := config.NewSink(lumber, buildLevel(o.File.Level))
+		writer := zapcore.AddSync(sink.Writer)
+		corefile := zapcore.NewCore(buildEncoder(o.File.Formatter), writer, levelEnabler{level: sink.Level})
 		cores = append(cores, corefile)
 		writers = append(writers, lumber)
+		byName[sinkFile] = sink
 	}
 
 	combinedCore := zapcore.NewTee(cores...)
-	combinedWriter := io.MultiWriter(writers...)
 
-	return combinedCore, combinedWriter
+	return combinedCore, writers, byName
 }
 
 func newLogger(o *Option, fields ...logr.Field) *logger {
-	core, writer := buildCoreAndWriter(o)
+	l := &logger{
+		root:   &atomic.Pointer[backend]{},
+		fields: fields,
+	}
+	l.root.Store(newBackend(o))
+	return l
+}
+
+func newBackend(o *Option) *backend {
+	core, writers, byName := buildCoreAndWriters(o)
 
-	sugar := zap.New(core,
-		zap.AddCaller(),
+	b := &backend{
+		writer:  io.MultiWriter(writers...),
+		writers: writers,
+		byName:  byName,
+		option:  o,
+	}
+	if o.Sampling.Initial > 0 {
+		b.sampler = logr.NewSampler(o.Sampling.Initial, o.Sampling.Thereafter, o.Sampling.Interval)
+		core = samplingCore{Core: core, sampler: b.sampler}
+	}
+	b.logger = zap.New(maskingCore{Core: core},
+		zap.WithCaller(o.AddSource),
 		zap.AddCallerSkip(callerSkip),
+		zap.WithFatalHook(fatalHook{backend: b}),
 	).Sugar()
-
-	l := &logger{
-		option: o,
-		logger: sugar,
-		writer: writer,
-		fields: fields,
+	if b.sampler != nil {
+		b.sampler.Report(b.reporter().reportSampling)
 	}
+	return b
+}
+
+// reporter returns a logger that writes straight to b, for the records the
+// backend logs itself.
+func (b *backend) reporter() *logger {
+	l := &logger{root: &atomic.Pointer[backend]{}}
+	l.root.Store(b)
 	return l
 }
 
+// reportSampling logs how many records the sampler suppressed.
+func (l *logger) reportSampling(suppressed uint64) {
+	l.Warnw(logr.SamplingMessage,
+		logr.Uint64("suppressed", suppressed),
+		logr.Duration("interval", l.root.Load().option.Sampling.Interval),
+	)
+}
+
+// Sinks implements config.Backend.
+func (b *backend) Sinks() map[string]config.Sink {
+	return b.byName
+}
+
+// Close implements config.Backend. It stops the sampler and closes the
+// writers as config.Close does.
+func (b *backend) Close() error {
+	if b.sampler != nil {
+		b.sampler.Stop()
+	}
+	return config.Close[*lumberjack.Logger](b.writers)
+}
+
+// fatal ends Fatal once the record is written; see config.Fatal.
+func (b *backend) fatal() {
+	config.Fatal(b.writers, b.option.OnFatal)
+}
+
+// fatalHook replaces the os.Exit zap calls after a Fatal record, so the
+// shutdown hooks run and tests can keep the process alive.
+type fatalHook struct {
+	backend *backend
+}
+
+// OnWrite implements zapcore.CheckWriteHook.
+func (h fatalHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {
+	h.backend.fatal()
+}
+
 func options(fns []FnOption) *Option {
 	option := defaultOption()
 
@@ -188,3 +490,45 @@ func options(fns []FnOption) *Option {
 	}
 	return option
 }
+
+// maskingCore masks the message with logr.MaskMessage before the entry
+// reaches the sinks. Fields are masked when they are built.
+type maskingCore struct {
+	zapcore.Core
+}
+
+// With implements zapcore.Core.
+func (c maskingCore) With(fields []zapcore.Field) zapcore.Core {
+	return maskingCore{Core: c.Core.With(fields)}
+}
+
+// Check implements zapcore.Core. The sinks keep the entry given here, so it
+// is masked before they are checked.
+func (c maskingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	ent.Message = logr.MaskMessage(ent.Message)
+	return c.Core.Check(ent, ce)
+}
+
+// samplingCore drops the entries its sampler does not keep before they reach
+// the sinks. zapcore's sampler is not used because it samples Panic and Fatal
+// entries too.
+type samplingCore struct {
+	zapcore.Core
+	sampler *logr.Sampler
+}
+
+// With implements zapcore.Core.
+func (c samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return samplingCore{Core: c.Core.With(fields), sampler: c.sampler}
+}
+
+// Check implements zapcore.Core.
+func (c samplingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) || !c.sampler.Sample(fromZapLevel(ent.Level), ent.Message) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,110 @@
+package zap_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/adapters/zap.v1"
+	"github.com/BrunoTulio/logr/logrtest/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, func(w io.Writer, level logr.Level) logr.Logger {
+		l := zap.New(zap.WithConsole(true), zap.WithConsoleWriter(w), zap.WithConsoleFormatter("JSON"))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestFatal(t *testing.T) {
+	conformance.RunFatal(t, func(w io.Writer, onFatal func()) logr.Logger {
+		return zap.New(zap.WithConsole(true), zap.WithConsoleWriter(w), zap.WithConsoleFormatter("JSON"), zap.WithOnFatal(onFatal))
+	})
+}
+
+func TestAsync(t *testing.T) {
+	var buf bytes.Buffer
+	l := zap.New(zap.WithConsole(true), zap.WithConsoleWriter(&buf), zap.WithConsoleFormatter("JSON"), zap.WithAsync(8, logr.OverflowBlock))
+
+	for i := range 100 {
+		l.Infof("record %d", i)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(conformance.Decode(t, &buf)); got != 100 {
+		t.Errorf("got %d records after Close, want 100", got)
+	}
+	if dropped := l.(logr.DropCounter).AsyncDropped(); len(dropped) != 1 || dropped["console"] != 0 {
+		t.Errorf("AsyncDropped() = %v, want the console sink with no drops", dropped)
+	}
+}
+
+func TestSampling(t *testing.T) {
+	conformance.RunSampling(t, func(w io.Writer, initial, thereafter int, interval time.Duration) logr.Logger {
+		return zap.New(zap.WithConsole(true), zap.WithConsoleWriter(w), zap.WithConsoleFormatter("JSON"), zap.WithSampling(initial, thereafter, interval))
+	})
+}
+
+func TestContextExtractors(t *testing.T) {
+	conformance.RunContextExtractors(t, func(w io.Writer, level logr.Level, extractor logr.ContextExtractor) logr.Logger {
+		l := zap.New(zap.WithConsole(true), zap.WithConsoleWriter(w), zap.WithConsoleFormatter("JSON"), zap.WithContextExtractor(extractor))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestCaller(t *testing.T) {
+	conformance.RunCaller(t, func(w io.Writer) logr.Logger {
+		return zap.New(zap.WithConsole(true), zap.WithConsoleWriter(w), zap.WithConsoleFormatter("JSON"), zap.WithAddSource(true))
+	})
+}
+
+func TestSinkLevels(t *testing.T) {
+	var console bytes.Buffer
+	dir := t.TempDir()
+	l := zap.New(
+		zap.WithConsole(true), zap.WithConsoleWriter(&console), zap.WithConsoleFormatter("JSON"), zap.WithConsoleLevel(logr.LevelWarn),
+		zap.WithFile(true, dir, "app.log"), zap.WithFileFormatter("JSON"), zap.WithFileLevel(logr.LevelDebug),
+	)
+	levels := l.(interface {
+		SinkLevels() map[string]*logr.AtomicLevel
+	}).SinkLevels()
+
+	l.Info("file only")
+	levels["console"].SetLevel(logr.LevelInfo)
+	l.Info("both")
+	l.SetLevel(logr.LevelError)
+	l.Warn("none")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for sink, tt := range map[string]struct {
+		r    io.Reader
+		want []string
+	}{
+		"console": {&console, []string{"both"}},
+		"file":    {file, []string{"file only", "both"}},
+	} {
+		var got []string
+		for _, r := range conformance.Decode(t, tt.r) {
+			got = append(got, r.Message())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s got %q, want %q", sink, got, tt.want)
+		}
+	}
+}
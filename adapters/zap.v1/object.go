@@ -0,0 +1,87 @@
+package zap
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/BrunoTulio/logr"
+)
+
+var (
+	_ zapcore.ObjectMarshaler = objectMarshaler{}
+	_ logr.ObjectEncoder      = objectEncoder{}
+)
+
+// objectMarshaler exposes a logr.ObjectMarshaler as a zapcore.ObjectMarshaler.
+type objectMarshaler struct {
+	object logr.ObjectMarshaler
+}
+
+func (m objectMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if err := m.object.MarshalLogObject(objectEncoder{enc: enc}); err != nil {
+		// Mantém o erro dentro do objeto, como nos demais adapters
+		enc.AddString(logr.ErrorKey, err.Error())
+	}
+	return nil
+}
+
+// objectEncoder forwards the fields of a logr.ObjectMarshaler to zap.
+type objectEncoder struct {
+	enc zapcore.ObjectEncoder
+}
+
+func (e objectEncoder) AddString(key, value string) {
+	e.enc.AddString(key, value)
+}
+
+func (e objectEncoder) AddBool(key string, value bool) {
+	e.enc.AddBool(key, value)
+}
+
+func (e objectEncoder) AddInt(key string, value int) {
+	e.enc.AddInt(key, value)
+}
+
+func (e objectEncoder) AddInt64(key string, value int64) {
+	e.enc.AddInt64(key, value)
+}
+
+func (e objectEncoder) AddUint64(key string, value uint64) {
+	e.enc.AddUint64(key, value)
+}
+
+func (e objectEncoder) AddFloat64(key string, value float64) {
+	e.enc.AddFloat64(key, value)
+}
+
+func (e objectEncoder) AddTime(key string, value time.Time) {
+	e.enc.AddTime(key, value)
+}
+
+func (e objectEncoder) AddDuration(key string, value time.Duration) {
+	e.enc.AddDuration(key, value)
+}
+
+func (e objectEncoder) AddStrings(key string, value []string) {
+	_ = e.enc.AddArray(key, stringArray(value))
+}
+
+func (e objectEncoder) AddObject(key string, value logr.ObjectMarshaler) error {
+	return e.enc.AddObject(key, objectMarshaler{object: value})
+}
+
+func (e objectEncoder) AddAny(key string, value any) {
+	if err := e.enc.AddReflected(key, value); err != nil {
+		e.enc.AddString(key+"Error", err.Error())
+	}
+}
+
+type stringArray []string
+
+func (a stringArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, s := range a {
+		enc.AppendString(s)
+	}
+	return nil
+}
@@ -1,35 +1,116 @@
 package zerolog
 
 import (
-	"time"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/rs/zerolog"
 
 	"github.com/BrunoTulio/logr"
 )
 
+// buildContext adds fields to c. Top-level errors go through zerolog's own
+// error support (Err/AnErr, honouring ErrorFieldName and ErrorMarshalFunc)
+// instead of the plain message used inside groups. fields are redacted first,
+// nested ones along with their group.
+func buildContext(c zerolog.Context, fields []logr.Field) zerolog.Context {
+	fields = logr.RedactFields(fields)
+	attrs := buildAttrs(fields)
+	for _, f := range fields {
+		err, _ := f.Value.(error)
+		if f.Type != logr.ErrorType || err == nil {
+			continue
+		}
+
+		delete(attrs, f.Key)
+		if f.Key == zerolog.ErrorFieldName {
+			c = c.Err(err)
+		} else {
+			c = c.AnErr(f.Key, err)
+		}
+	}
+	return c.Fields(attrs)
+}
+
 func buildAttrs(fields []logr.Field) map[string]any {
 	m := make(map[string]any, len(fields))
 	for _, f := range fields {
-		switch f.Type {
-		case logr.StringType:
-			m[f.Key] = f.Value.(string)
-		case logr.BoolType:
-			m[f.Key] = f.Value.(bool)
-		case logr.IntType:
-			m[f.Key] = f.Value.(int)
-		case logr.Uint64Type:
-			m[f.Key] = f.Value.(uint64)
-		case logr.Float64Type:
-			m[f.Key] = f.Value.(float64)
-		case logr.TimeType:
-			m[f.Key] = f.Value.(time.Time)
-		case logr.DurationType:
-			m[f.Key] = f.Value.(time.Duration)
-		case logr.GroupType:
-			groupFields := f.Value.([]logr.Field)
-			m[f.Key] = buildAttrs(groupFields)
-		default:
-			// ignora tipos desconhecidos
+		if f.Type == logr.ErrorType {
+			addErrorValues(m, f)
+			continue
 		}
+		m[f.Key] = buildValue(f)
 	}
 	return m
 }
+
+// buildValue returns the value zerolog should encode for f. zerolog already
+// encodes numbers, strings, times and slices by their dynamic type, so those
+// (and values that do not match their FieldType) are passed through as is.
+func buildValue(f logr.Field) any {
+	switch f.Type {
+	case logr.BytesType:
+		if v, ok := f.Value.([]byte); ok {
+			return base64.StdEncoding.EncodeToString(v)
+		}
+	case logr.HexType:
+		if v, ok := f.Value.([]byte); ok {
+			return hex.EncodeToString(v)
+		}
+	case logr.StringerType:
+		if v, ok := f.Value.(fmt.Stringer); ok {
+			return fmt.Sprint(v)
+		}
+	case logr.GroupType:
+		if groupFields, ok := f.Value.([]logr.Field); ok {
+			return buildAttrs(groupFields)
+		}
+	case logr.ObjectType:
+		if v, ok := f.Value.(logr.ObjectMarshaler); ok {
+			return objectMarshaler{object: v}
+		}
+	case logr.SecretType:
+		return logr.RedactedValue
+	case logr.StringType, logr.BoolType, logr.IntType, logr.Int64Type, logr.Int32Type,
+		logr.UintType, logr.Uint32Type, logr.Uint64Type, logr.Float32Type, logr.Float64Type,
+		logr.TimeType, logr.DurationType, logr.StringsType, logr.IntsType, logr.AnyType, logr.ErrorType,
+		logr.LazyType, logr.LazyGroupType:
+	}
+	return f.Value
+}
+
+// addErrorValues adds an ErrorType field to a map rendered by reflection,
+// where an error value would otherwise be encoded as an empty object.
+func addErrorValues(m map[string]any, f logr.Field) {
+	err, _ := f.Value.(error)
+	if err == nil {
+		return
+	}
+	m[f.Key] = err.Error()
+	m[f.Key+logr.ErrorChainSuffix] = logr.ErrorChain(err)
+	if verbose := logr.ErrorVerbose(err); verbose != "" {
+		m[f.Key+logr.ErrorVerboseSuffix] = verbose
+	}
+}
+
+// buildEvent adds per-call fields to e, the same way buildContext does for the
+// fields of a logger.
+func buildEvent(e *zerolog.Event, fields []logr.Field) *zerolog.Event {
+	fields = logr.RedactFields(fields)
+	attrs := buildAttrs(fields)
+	for _, f := range fields {
+		err, _ := f.Value.(error)
+		if f.Type != logr.ErrorType || err == nil {
+			continue
+		}
+
+		delete(attrs, f.Key)
+		if f.Key == zerolog.ErrorFieldName {
+			e = e.Err(err)
+		} else {
+			e = e.AnErr(f.Key, err)
+		}
+	}
+	return e.Fields(attrs)
+}
@@ -0,0 +1,15 @@
+module github.com/BrunoTulio/logr/adapters/zerolog.v1
+
+go 1.22.10
+
+require (
+	github.com/BrunoTulio/logr v0.1.0
+	github.com/rs/zerolog v1.34.0
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/sys v0.12.0 // indirect
+)
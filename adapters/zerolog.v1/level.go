@@ -1,18 +1,88 @@
 package zerolog
 
-import "github.com/rs/zerolog"
+import (
+	"io"
 
-func buildLevel(level string) zerolog.Level {
+	"github.com/rs/zerolog"
+
+	"github.com/BrunoTulio/logr"
+)
+
+const (
+	sinkConsole = "console"
+	sinkFile    = "file"
+)
+
+// levelWriter drops events below its sink level, so every sink can be
+// filtered independently and changed while the logger is running.
+type levelWriter struct {
+	io.Writer
+	level *logr.AtomicLevel
+}
+
+func (w levelWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < toZerologLevel(w.level.Level()) {
+		return len(p), nil
+	}
+	return w.Write(p)
+}
+
+// buildLevel returns the level named level, or INFO when it is empty or
+// invalid.
+func buildLevel(level string) logr.Level {
+	if parsed, err := logr.ParseLevel(level); err == nil {
+		return parsed
+	}
+	return logr.LevelInfo
+}
+
+// buildFormat returns the format named format, or JSON, the format of
+// zerolog, when it is empty or invalid.
+func buildFormat(format string) logr.Format {
+	if parsed, err := logr.ParseFormat(format); err == nil {
+		return parsed
+	}
+	return logr.FormatJSON
+}
+
+func toZerologLevel(level logr.Level) zerolog.Level {
 	switch level {
-	case "DEBUG":
+	case logr.LevelTrace:
+		return zerolog.TraceLevel
+	case logr.LevelDebug:
 		return zerolog.DebugLevel
-	case "INFO":
+	case logr.LevelInfo:
 		return zerolog.InfoLevel
-	case "WARN":
+	case logr.LevelWarn:
 		return zerolog.WarnLevel
-	case "ERROR":
+	case logr.LevelError:
 		return zerolog.ErrorLevel
+	case logr.LevelPanic:
+		return zerolog.PanicLevel
+	case logr.LevelFatal:
+		return zerolog.FatalLevel
 	default:
 		return zerolog.InfoLevel
 	}
 }
+
+func fromZerologLevel(level zerolog.Level) logr.Level {
+	switch level {
+	case zerolog.TraceLevel:
+		return logr.LevelTrace
+	case zerolog.DebugLevel:
+		return logr.LevelDebug
+	case zerolog.InfoLevel:
+		return logr.LevelInfo
+	case zerolog.WarnLevel:
+		return logr.LevelWarn
+	case zerolog.ErrorLevel:
+		return logr.LevelError
+	case zerolog.PanicLevel:
+		return logr.LevelPanic
+	case zerolog.FatalLevel:
+		return logr.LevelFatal
+	default:
+		return logr.LevelInfo
+	}
+}
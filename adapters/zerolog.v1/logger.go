@@ -2,67 +2,178 @@ package zerolog
 
 import (
 	"context"
+	"fmt"
 	"io"
-	"os"
 	"path"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
 )
 
 type ctxKey struct{}
 
-const callerSkip = 8
+// callerSkip is the number of frames between the hook zerolog adds for the
+// caller and the code calling the logr method: Event.msg, Event.Msg, msg or
+// msgf and the method itself.
+const callerSkip = 4
 
-var _ logr.Logger = (*logger)(nil)
+var (
+	_ logr.Logger      = (*logger)(nil)
+	_ logr.DropCounter = (*logger)(nil)
+)
 
-type logger struct {
-	logger *zerolog.Logger
-	writer io.Writer
-	fields logr.Fields
-	option *Option
-}
+type (
+	logger struct {
+		// root is shared by every logger derived from the same New and is
+		// replaced by Reload.
+		root   *atomic.Pointer[backend]
+		bound  atomic.Pointer[bound]
+		fields logr.Fields
+		lazy   logr.Fields
+	}
+
+	// backend is what Reload rebuilds from a new config.
+	backend struct {
+		logger *zerolog.Logger
+		writer io.Writer
+		// writers are the destinations of the sinks, under the formatting
+		// and level filtering of writer.
+		writers []io.Writer
+		byName  map[string]config.Sink
+		option  *Option
+		// sampler is nil unless WithSampling was given.
+		sampler *logr.Sampler
+	}
+
+	// bound is the backend logger extended with the eager fields of one
+	// logger.
+	bound struct {
+		backend *backend
+		logger  *zerolog.Logger
+	}
+)
 
 // Debug implements logr.Logger.
 func (l *logger) Debug(message string) {
-	l.logger.Debug().Msg(message)
+	msg(l.event(logr.LevelDebug), message)
 }
 
 // Debugf implements logr.Logger.
 func (l *logger) Debugf(format string, args ...interface{}) {
-	l.logger.Debug().Msgf(format, args...)
+	msgf(l.event(logr.LevelDebug), format, args...)
+}
+
+// DebugContext implements logr.Logger.
+func (l *logger) DebugContext(ctx context.Context, message string) {
+	if e := l.event(logr.LevelDebug); e != nil {
+		msg(buildEvent(e.Ctx(ctx), logr.Resolve(l.contextFields(ctx))), message)
+	}
+}
+
+// DebugfContext implements logr.Logger.
+func (l *logger) DebugfContext(ctx context.Context, format string, args ...interface{}) {
+	if e := l.event(logr.LevelDebug); e != nil {
+		msgf(buildEvent(e.Ctx(ctx), logr.Resolve(l.contextFields(ctx))), format, args...)
+	}
+}
+
+// Debugw implements logr.Logger.
+func (l *logger) Debugw(message string, fields ...logr.Field) {
+	if e := l.event(logr.LevelDebug); e != nil {
+		msg(buildEvent(e, logr.Resolve(fields)), message)
+	}
 }
 
 // Error implements logr.Logger.
 func (l *logger) Error(message string) {
-	l.logger.Error().Msg(message)
+	msg(l.event(logr.LevelError), message)
 }
 
 // Errorf implements logr.Logger.
 func (l *logger) Errorf(format string, args ...interface{}) {
-	l.logger.Error().Msgf(format, args...)
+	msgf(l.event(logr.LevelError), format, args...)
+}
+
+// ErrorContext implements logr.Logger.
+func (l *logger) ErrorContext(ctx context.Context, message string) {
+	if e := l.event(logr.LevelError); e != nil {
+		msg(buildEvent(e.Ctx(ctx), logr.Resolve(l.contextFields(ctx))), message)
+	}
+}
+
+// ErrorfContext implements logr.Logger.
+func (l *logger) ErrorfContext(ctx context.Context, format string, args ...interface{}) {
+	if e := l.event(logr.LevelError); e != nil {
+		msgf(buildEvent(e.Ctx(ctx), logr.Resolve(l.contextFields(ctx))), format, args...)
+	}
 }
 
-// Fatal implements logr.Logger.
+// Errorw implements logr.Logger.
+func (l *logger) Errorw(message string, fields ...logr.Field) {
+	if e := l.event(logr.LevelError); e != nil {
+		msg(buildEvent(e, logr.Resolve(fields)), message)
+	}
+}
+
+// Fatal implements logr.Logger. zerolog's own Fatal event is not used: it
+// calls os.Exit without the shutdown hooks.
 func (l *logger) Fatal(message string) {
-	l.logger.Fatal().Msg(message)
+	msg(l.event(logr.LevelFatal), message)
+	l.root.Load().fatal()
 }
 
 // Fatalf implements logr.Logger.
 func (l *logger) Fatalf(format string, args ...interface{}) {
-	l.logger.Fatal().Msgf(format, args...)
+	msgf(l.event(logr.LevelFatal), format, args...)
+	l.root.Load().fatal()
+}
+
+// Panic implements logr.Logger.
+func (l *logger) Panic(message string) {
+	msg(l.at(logr.LevelPanic).Panic(), message)
+}
+
+// Panicf implements logr.Logger.
+func (l *logger) Panicf(format string, args ...interface{}) {
+	msgf(l.at(logr.LevelPanic).Panic(), format, args...)
+}
+
+// Trace implements logr.Logger.
+func (l *logger) Trace(message string) {
+	msg(l.event(logr.LevelTrace), message)
+}
+
+// Tracef implements logr.Logger.
+func (l *logger) Tracef(format string, args ...interface{}) {
+	msgf(l.event(logr.LevelTrace), format, args...)
+}
+
+// Log implements logr.Logger.
+func (l *logger) Log(level logr.Level, message string, fields ...logr.Field) {
+	if level == logr.LevelPanic {
+		message = logr.MaskMessage(message)
+	}
+	if e := l.event(level); e != nil {
+		msg(buildEvent(e, logr.Resolve(fields)), message)
+	}
+	// WithLevel não entra em pânico nem encerra o processo como Panic e Fatal
+	switch level {
+	case logr.LevelPanic:
+		panic(message)
+	case logr.LevelFatal:
+		l.root.Load().fatal()
+	}
 }
 
 // FromContext implements logr.Logger.
 func (l *logger) FromContext(ctx context.Context) logr.Logger {
-	fields, ok := ctx.Value(ctxKey{}).(logr.Fields)
-	if !ok {
-		fields = logr.Fields{}
-	}
-	return l.WithFields(fields...)
+	return l.withContext(ctx)
 }
 
 // GetFields implements logr.Logger.
@@ -72,17 +183,76 @@ func (l *logger) GetFields() logr.Fields {
 
 // Info implements logr.Logger.
 func (l *logger) Info(message string) {
-	l.logger.Info().Msg(message)
+	msg(l.event(logr.LevelInfo), message)
 }
 
 // Infof implements logr.Logger.
 func (l *logger) Infof(format string, args ...interface{}) {
-	l.logger.Info().Msgf(format, args...)
+	msgf(l.event(logr.LevelInfo), format, args...)
+}
+
+// InfoContext implements logr.Logger.
+func (l *logger) InfoContext(ctx context.Context, message string) {
+	if e := l.event(logr.LevelInfo); e != nil {
+		msg(buildEvent(e.Ctx(ctx), logr.Resolve(l.contextFields(ctx))), message)
+	}
+}
+
+// InfofContext implements logr.Logger.
+func (l *logger) InfofContext(ctx context.Context, format string, args ...interface{}) {
+	if e := l.event(logr.LevelInfo); e != nil {
+		msgf(buildEvent(e.Ctx(ctx), logr.Resolve(l.contextFields(ctx))), format, args...)
+	}
+}
+
+// Infow implements logr.Logger.
+func (l *logger) Infow(message string, fields ...logr.Field) {
+	if e := l.event(logr.LevelInfo); e != nil {
+		msg(buildEvent(e, logr.Resolve(fields)), message)
+	}
+}
+
+// Level implements logr.Logger.
+func (l *logger) Level() logr.Level {
+	return config.LowestLevel(l.root.Load().byName)
+}
+
+// SetLevel implements logr.Logger.
+func (l *logger) SetLevel(level logr.Level) {
+	config.SetLevel(l.root.Load().byName, level)
+}
+
+// Enabled implements logr.Logger.
+func (l *logger) Enabled(level logr.Level) bool {
+	return config.Enabled(l.root.Load().byName, level)
+}
+
+// SinkLevels returns the level of every enabled sink, keyed by sink name
+// ("console", "file"). Changing a returned level affects every logger derived
+// from l.
+func (l *logger) SinkLevels() map[string]*logr.AtomicLevel {
+	return config.SinkLevels(l.root.Load().byName)
+}
+
+// AsyncDropped implements logr.DropCounter. Only the sinks written through
+// WithAsync are included, and their counts survive Reload.
+func (l *logger) AsyncDropped() map[string]uint64 {
+	return config.Dropped(l.root.Load().byName)
 }
 
 // Output implements logr.Logger.
 func (l *logger) Output() io.Writer {
-	return l.writer
+	return l.root.Load().writer
+}
+
+// Sync implements logr.Logger.
+func (l *logger) Sync() error {
+	return config.Sync(l.root.Load().writers)
+}
+
+// Close implements logr.Logger.
+func (l *logger) Close() error {
+	return l.root.Load().Close()
 }
 
 // ToContext implements logr.Logger.
@@ -92,12 +262,33 @@ func (l *logger) ToContext(ctx context.Context) context.Context {
 
 // Warn implements logr.Logger.
 func (l *logger) Warn(message string) {
-	l.logger.Warn().Msg(message)
+	msg(l.event(logr.LevelWarn), message)
 }
 
 // Warnf implements logr.Logger.
 func (l *logger) Warnf(format string, args ...interface{}) {
-	l.logger.Warn().Msgf(format, args...)
+	msgf(l.event(logr.LevelWarn), format, args...)
+}
+
+// WarnContext implements logr.Logger.
+func (l *logger) WarnContext(ctx context.Context, message string) {
+	if e := l.event(logr.LevelWarn); e != nil {
+		msg(buildEvent(e.Ctx(ctx), logr.Resolve(l.contextFields(ctx))), message)
+	}
+}
+
+// WarnfContext implements logr.Logger.
+func (l *logger) WarnfContext(ctx context.Context, format string, args ...interface{}) {
+	if e := l.event(logr.LevelWarn); e != nil {
+		msgf(buildEvent(e.Ctx(ctx), logr.Resolve(l.contextFields(ctx))), format, args...)
+	}
+}
+
+// Warnw implements logr.Logger.
+func (l *logger) Warnw(message string, fields ...logr.Field) {
+	if e := l.event(logr.LevelWarn); e != nil {
+		msg(buildEvent(e, logr.Resolve(fields)), message)
+	}
 }
 
 // WithField implements logr.Logger.
@@ -107,17 +298,70 @@ func (l *logger) WithField(field logr.Field) logr.Logger {
 
 // WithFields implements logr.Logger.
 func (l *logger) WithFields(fields ...logr.Field) logr.Logger {
-	//nolint:gocritic // appendAssign: necessário criar nova slice para manter imutabilidade
-	newFields := append(l.fields, fields...)
-	args := buildAttrs(newFields)
-	newLogger := l.logger.With().Fields(args).Logger()
+	eager, lazy := logr.SplitLazy(fields)
+
+	parent := l.bind()
+	newLogger := buildContext(parent.logger.With(), eager).Logger()
+	derived := &logger{
+		root:   l.root,
+		fields: slices.Concat(l.fields, fields),
+		lazy:   slices.Concat(l.lazy, lazy),
+	}
+	derived.bound.Store(&bound{backend: parent.backend, logger: &newLogger})
+	return derived
+}
+
+// bind returns the zerolog logger with the eager fields of l, rebuilding it
+// when a Reload replaced the backend it was bound to.
+func (l *logger) bind() *bound {
+	current := l.root.Load()
+	if b := l.bound.Load(); b != nil && b.backend == current {
+		return b
+	}
+	eager, _ := logr.SplitLazy(l.fields)
+	newLogger := buildContext(current.logger.With(), eager).Logger()
+	b := &bound{backend: current, logger: &newLogger}
+	l.bound.Store(b)
+	return b
+}
+
+// at returns the zerolog logger for a record at level, extended with the
+// lazy fields only when that record is going to be written.
+func (l *logger) at(level logr.Level) *zerolog.Logger {
+	current := l.bind().logger
+	if len(l.lazy) == 0 || !l.Enabled(level) {
+		return current
+	}
+	withLazy := buildContext(current.With(), logr.Resolve(l.lazy)).Logger()
+	return &withLazy
+}
+
+// event starts a zerolog event, or returns nil (a no-op event) when no sink
+// accepts level.
+func (l *logger) event(level logr.Level) *zerolog.Event {
+	if !l.Enabled(level) {
+		return nil
+	}
+	return l.at(level).WithLevel(toZerologLevel(level))
+}
 
-	return &logger{
-		option: l.option,
-		logger: &newLogger,
-		writer: l.writer,
-		fields: newFields,
+// withContext returns l extended with the fields stored in ctx by ToContext
+// and those returned by the configured context extractors.
+func (l *logger) withContext(ctx context.Context) *logger {
+	fields := l.contextFields(ctx)
+	if len(fields) == 0 {
+		return l
 	}
+	return l.WithFields(fields...).(*logger)
+}
+
+// contextFields returns the fields stored in ctx by ToContext followed by
+// those returned by the context extractors, without the keys l already
+// carries (see logr.ContextFields). The *Context methods log them with the
+// record, without deriving a logger.
+func (l *logger) contextFields(ctx context.Context) logr.Fields {
+	stored, _ := ctx.Value(ctxKey{}).(logr.Fields)
+	return logr.ContextFields(ctx, stored, l.fields, l.root.Load().option.ContextExtractors)
 }
 
 func New(fns ...FnOption) logr.Logger {
@@ -125,6 +369,16 @@ func New(fns ...FnOption) logr.Logger {
 	return NewWithOption(option)
 }
 
+// NewE is like New, but reports invalid levels and formats instead of
+// falling back to INFO and JSON.
+func NewE(fns ...FnOption) (logr.Logger, error) {
+	option := options(fns)
+	if err := option.validate(); err != nil {
+		return nil, err
+	}
+	return NewWithOption(option), nil
+}
+
 func NewWithOption(o *Option) logr.Logger {
 	l := newLogger(o)
 	logr.Set(l)
@@ -132,59 +386,122 @@ func NewWithOption(o *Option) logr.Logger {
 }
 
 func newLogger(o *Option, fields ...logr.Field) *logger {
-	log, writer := buildLoggerAndWriter(o)
 	l := &logger{
-		option: o,
-		logger: &log,
-		writer: writer,
+		root:   &atomic.Pointer[backend]{},
 		fields: fields,
 	}
+	l.root.Store(newBackend(o))
 	return l
 }
 
-func buildLoggerAndWriter(o *Option) (zerolog.Logger, io.Writer) {
-	var writers []io.Writer
+func newBackend(o *Option) *backend {
+	log, writer, writers, byName := buildLoggerAndWriters(o)
+	b := &backend{
+		writer:  writer,
+		writers: writers,
+		byName:  byName,
+		option:  o,
+	}
+	b.logger = &log
+	if o.Sampling.Initial > 0 {
+		b.sampler = logr.NewSampler(o.Sampling.Initial, o.Sampling.Thereafter, o.Sampling.Interval)
+		sampled := log.Hook(samplingHook{sampler: b.sampler})
+		b.logger = &sampled
+		b.sampler.Report(func(suppressed uint64) {
+			b.reportSampling(log, suppressed)
+		})
+	}
+	return b
+}
+
+// reportSampling logs through log, without the sampling hook, how many records
+// the sampler suppressed, so the record is not weighed against the other WARN
+// records.
+func (b *backend) reportSampling(log zerolog.Logger, suppressed uint64) {
+	buildEvent(log.Warn(), logr.Fields{
+		logr.Uint64("suppressed", suppressed),
+		logr.Duration("interval", b.option.Sampling.Interval),
+	}).Msg(logr.SamplingMessage)
+}
 
-	// Configura formato de hora padrão
-	zerolog.TimeFieldFormat = time.RFC3339
+// Sinks implements config.Backend.
+func (b *backend) Sinks() map[string]config.Sink {
+	return b.byName
+}
 
-	// Ajusta nível padrão
-	level := buildLevel(o.Level)
+// Close implements config.Backend. It stops the sampler and closes the
+// writers as config.Close does.
+func (b *backend) Close() error {
+	if b.sampler != nil {
+		b.sampler.Stop()
+	}
+	return config.Close[*lumberjack.Logger](b.writers)
+}
+
+// fatal ends Fatal once the record is written; see config.Fatal.
+func (b *backend) fatal() {
+	config.Fatal(b.writers, b.option.OnFatal)
+}
+
+func buildLoggerAndWriters(o *Option) (zerolog.Logger, io.Writer, []io.Writer, map[string]config.Sink) {
+	var writers, outputs []io.Writer
+	byName := make(map[string]config.Sink)
+
+	// Configura formato de hora padrão; a variável é global do zerolog, então
+	// só é escrita quando muda, e não a cada Reload
+	if zerolog.TimeFieldFormat != time.RFC3339 {
+		zerolog.TimeFieldFormat = time.RFC3339
+	}
 
 	// Console (stdout)
 	if o.Console.Enabled {
-		writers = append(writers, createWriter(os.Stdout, o.Formatter, o.Console.ApplyColor))
+		consoleWriter := o.async(o.consoleWriter())
+		console := config.NewSink(consoleWriter, buildLevel(o.sinkLevel(o.Console.Level)))
+		writers = append(writers, levelWriter{
+			Writer: createWriter(console.Writer, o.sinkFormatter(o.Console.Formatter), o.Console.ApplyColor),
+			level:  console.Level,
+		})
+		outputs = append(outputs, consoleWriter)
+		byName[sinkConsole] = console
 	}
 
 	// Arquivo (com rotação via lumberjack)
 	if o.File.Enabled {
-		fileWriter := &lumberjack.Logger{
+		fileWriter := o.async(&lumberjack.Logger{
 			Filename: path.Join(o.File.Path, o.File.Name),
 			MaxSize:  o.File.MaxSize,
 			MaxAge:   o.File.MaxAge,
 			Compress: o.File.Compress,
-		}
-		writers = append(writers, createWriter(fileWriter, o.Formatter, false))
+		})
+		file := config.NewSink(fileWriter, buildLevel(o.sinkLevel(o.File.Level)))
+		writers = append(writers, levelWriter{
+			Writer: createWriter(file.Writer, o.sinkFormatter(o.File.Formatter), false),
+			level:  file.Level,
+		})
+		outputs = append(outputs, fileWriter)
+		byName[sinkFile] = file
 	}
 
 	if len(writers) == 0 {
 		writers = append(writers, io.Discard)
 	}
 
-	multi := io.MultiWriter(writers...)
+	multi := zerolog.MultiLevelWriter(writers...)
 
-	logger := zerolog.New(multi).
-		Level(level).
+	// O filtro por nível fica a cargo de cada levelWriter
+	ctx := zerolog.New(multi).
+		Level(zerolog.TraceLevel).
 		With().
-		Timestamp().
-		CallerWithSkipFrameCount(callerSkip). // se quiser o arquivo/linha
-		Logger()
+		Timestamp()
+	if o.AddSource {
+		ctx = ctx.CallerWithSkipFrameCount(callerSkip)
+	}
 
-	return logger, multi
+	return ctx.Logger(), multi, outputs, byName
 }
 
 func createWriter(out io.Writer, formatter string, applyColor bool) io.Writer {
-	if formatter == "TEXT" {
+	if buildFormat(formatter) == logr.FormatText {
 		w := zerolog.ConsoleWriter{
 			Out:        out,
 			TimeFormat: time.RFC3339,
@@ -203,3 +520,18 @@ func options(fns []FnOption) *Option {
 	}
 	return option
 }
+
+// msg writes e with message masked by logr.MaskMessage. A nil e is a
+// disabled record, whose message is not even masked.
+func msg(e *zerolog.Event, message string) {
+	if e != nil {
+		e.Msg(logr.MaskMessage(message))
+	}
+}
+
+// msgf is like msg, formatting the message only for an enabled record.
+func msgf(e *zerolog.Event, format string, args ...interface{}) {
+	if e != nil {
+		e.Msg(logr.MaskMessage(fmt.Sprintf(format, args...)))
+	}
+}
@@ -0,0 +1,119 @@
+package zerolog_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/adapters/zerolog.v1"
+	"github.com/BrunoTulio/logr/config"
+	"github.com/BrunoTulio/logr/logrtest/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, func(w io.Writer, level logr.Level) logr.Logger {
+		l := zerolog.New(zerolog.WithConsole(true), zerolog.WithConsoleWriter(w), zerolog.WithFormatter("JSON"))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestNewFromConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Console = config.Console{Enabled: true, Level: "WARN", Format: "JSON"}
+
+	var buf bytes.Buffer
+	l, err := zerolog.NewFromConfig(cfg, zerolog.WithConsoleWriter(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Info("hidden")
+	l.Warn("shown")
+
+	records := conformance.Decode(t, &buf)
+	if len(records) != 1 || records[0].Message() != "shown" {
+		t.Errorf("records = %v", records)
+	}
+	if _, ok := records[0]["caller"]; ok {
+		t.Errorf("caller reported with config.Caller unset: %v", records[0])
+	}
+
+	cfg.Console.Format = "YAML"
+	if _, err := zerolog.NewFromConfig(cfg); err == nil || !strings.Contains(err.Error(), "console.format") {
+		t.Errorf("NewFromConfig error = %v", err)
+	}
+}
+
+func TestFatal(t *testing.T) {
+	conformance.RunFatal(t, func(w io.Writer, onFatal func()) logr.Logger {
+		return zerolog.New(zerolog.WithConsole(true), zerolog.WithConsoleWriter(w), zerolog.WithConsoleFormatter("JSON"), zerolog.WithOnFatal(onFatal))
+	})
+}
+
+func TestSampling(t *testing.T) {
+	conformance.RunSampling(t, func(w io.Writer, initial, thereafter int, interval time.Duration) logr.Logger {
+		return zerolog.New(zerolog.WithConsole(true), zerolog.WithConsoleWriter(w), zerolog.WithConsoleFormatter("JSON"), zerolog.WithSampling(initial, thereafter, interval))
+	})
+}
+
+func TestContextExtractors(t *testing.T) {
+	conformance.RunContextExtractors(t, func(w io.Writer, level logr.Level, extractor logr.ContextExtractor) logr.Logger {
+		l := zerolog.New(zerolog.WithConsole(true), zerolog.WithConsoleWriter(w), zerolog.WithConsoleFormatter("JSON"), zerolog.WithContextExtractor(extractor))
+		l.SetLevel(level)
+		return l
+	})
+}
+
+func TestCaller(t *testing.T) {
+	conformance.RunCaller(t, func(w io.Writer) logr.Logger {
+		return zerolog.New(zerolog.WithConsole(true), zerolog.WithConsoleWriter(w), zerolog.WithConsoleFormatter("JSON"), zerolog.WithAddSource(true))
+	})
+}
+
+func TestSinkLevels(t *testing.T) {
+	var console bytes.Buffer
+	dir := t.TempDir()
+	l := zerolog.New(
+		zerolog.WithConsole(true), zerolog.WithConsoleWriter(&console), zerolog.WithConsoleFormatter("JSON"), zerolog.WithConsoleLevel(logr.LevelWarn),
+		zerolog.WithFile(true, dir, "app.log"), zerolog.WithFileFormatter("JSON"), zerolog.WithFileLevel(logr.LevelDebug),
+	)
+	levels := l.(interface {
+		SinkLevels() map[string]*logr.AtomicLevel
+	}).SinkLevels()
+
+	l.Info("file only")
+	levels["console"].SetLevel(logr.LevelInfo)
+	l.Info("both")
+	l.SetLevel(logr.LevelError)
+	l.Warn("none")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for sink, tt := range map[string]struct {
+		r    io.Reader
+		want []string
+	}{
+		"console": {&console, []string{"both"}},
+		"file":    {file, []string{"file only", "both"}},
+	} {
+		var got []string
+		for _, r := range conformance.Decode(t, tt.r) {
+			got = append(got, r.Message())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s got %q, want %q", sink, got, tt.want)
+		}
+	}
+}
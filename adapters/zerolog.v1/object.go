@@ -0,0 +1,141 @@
+package zerolog
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/BrunoTulio/logr"
+)
+
+var (
+	_ zerolog.LogObjectMarshaler = objectMarshaler{}
+	_ json.Marshaler             = objectMarshaler{}
+	_ logr.ObjectEncoder         = eventEncoder{}
+	_ logr.ObjectEncoder         = mapEncoder{}
+)
+
+// objectMarshaler exposes a logr.ObjectMarshaler as a
+// zerolog.LogObjectMarshaler. Inside groups, which zerolog encodes with
+// encoding/json, it falls back to MarshalJSON.
+type objectMarshaler struct {
+	object logr.ObjectMarshaler
+}
+
+func (m objectMarshaler) MarshalZerologObject(e *zerolog.Event) {
+	if err := m.object.MarshalLogObject(eventEncoder{event: e}); err != nil {
+		e.Str(logr.ErrorKey, err.Error())
+	}
+}
+
+func (m objectMarshaler) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalMap(m.object))
+}
+
+func marshalMap(object logr.ObjectMarshaler) map[string]any {
+	enc := mapEncoder{}
+	if err := object.MarshalLogObject(enc); err != nil {
+		enc[logr.ErrorKey] = err.Error()
+	}
+	return enc
+}
+
+// eventEncoder forwards the fields of a logr.ObjectMarshaler to a zerolog
+// event.
+type eventEncoder struct {
+	event *zerolog.Event
+}
+
+func (e eventEncoder) AddString(key, value string) {
+	e.event.Str(key, value)
+}
+
+func (e eventEncoder) AddBool(key string, value bool) {
+	e.event.Bool(key, value)
+}
+
+func (e eventEncoder) AddInt(key string, value int) {
+	e.event.Int(key, value)
+}
+
+func (e eventEncoder) AddInt64(key string, value int64) {
+	e.event.Int64(key, value)
+}
+
+func (e eventEncoder) AddUint64(key string, value uint64) {
+	e.event.Uint64(key, value)
+}
+
+func (e eventEncoder) AddFloat64(key string, value float64) {
+	e.event.Float64(key, value)
+}
+
+func (e eventEncoder) AddTime(key string, value time.Time) {
+	e.event.Time(key, value)
+}
+
+func (e eventEncoder) AddDuration(key string, value time.Duration) {
+	e.event.Dur(key, value)
+}
+
+func (e eventEncoder) AddStrings(key string, value []string) {
+	e.event.Strs(key, value)
+}
+
+func (e eventEncoder) AddObject(key string, value logr.ObjectMarshaler) error {
+	e.event.Object(key, objectMarshaler{object: value})
+	return nil
+}
+
+func (e eventEncoder) AddAny(key string, value any) {
+	e.event.Interface(key, value)
+}
+
+// mapEncoder collects the fields of a logr.ObjectMarshaler in a map.
+type mapEncoder map[string]any
+
+func (e mapEncoder) AddString(key, value string) {
+	e[key] = value
+}
+
+func (e mapEncoder) AddBool(key string, value bool) {
+	e[key] = value
+}
+
+func (e mapEncoder) AddInt(key string, value int) {
+	e[key] = value
+}
+
+func (e mapEncoder) AddInt64(key string, value int64) {
+	e[key] = value
+}
+
+func (e mapEncoder) AddUint64(key string, value uint64) {
+	e[key] = value
+}
+
+func (e mapEncoder) AddFloat64(key string, value float64) {
+	e[key] = value
+}
+
+func (e mapEncoder) AddTime(key string, value time.Time) {
+	e[key] = value
+}
+
+func (e mapEncoder) AddDuration(key string, value time.Duration) {
+	e[key] = value
+}
+
+func (e mapEncoder) AddStrings(key string, value []string) {
+	e[key] = value
+}
+
+func (e mapEncoder) AddObject(key string, value logr.ObjectMarshaler) error {
+	e[key] = marshalMap(value)
+	return nil
+}
+
+func (e mapEncoder) AddAny(key string, value any) {
+	e[key] = value
+}
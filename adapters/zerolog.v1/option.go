@@ -1,38 +1,147 @@
 package zerolog
 
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
+)
+
 type FnOption func(option *Option)
 
 type Option struct {
+	// Level e Formatter valem para os sinks sem nível ou formato próprio
 	Level     string // DEBUG/INFO/WARN/ERROR
 	Formatter string // TEXT/JSON
 
 	Console struct {
 		Enabled    bool
+		Level      string
+		Formatter  string
 		ApplyColor bool
+		Writer     io.Writer
 	}
 	File struct {
-		Enabled  bool
-		Path     string
-		Name     string
-		MaxSize  int
-		Compress bool
-		MaxAge   int
+		Enabled   bool
+		Level     string
+		Formatter string
+		Path      string
+		Name      string
+		MaxSize   int
+		Compress  bool
+		MaxAge    int
+	}
+	// Async, when BufferSize is set, queues the records of every sink in a
+	// logr.AsyncWriter.
+	Async struct {
+		BufferSize int
+		Policy     logr.OverflowPolicy
+	}
+	// Sampling, when Initial is set, keeps the first Initial records with the
+	// same level and message in each Interval and then one of every
+	// Thereafter.
+	Sampling struct {
+		Initial    int
+		Thereafter int
+		Interval   time.Duration
 	}
+	AddSource         bool
+	ContextExtractors []logr.ContextExtractor
+	// OnFatal runs after Fatal has written its record and flushed the sinks.
+	// When nil, logr.Exit(1) runs the shutdown hooks and ends the process.
+	OnFatal func()
 }
 
 func defaultOption() *Option {
-	return &Option{}
+	return &Option{AddSource: true}
+}
+
+// sinkLevel returns the level of a sink, falling back to Option.Level.
+func (o *Option) sinkLevel(level string) string {
+	if level == "" {
+		return o.Level
+	}
+	return level
+}
+
+// sinkFormatter returns the formatter of a sink, falling back to
+// Option.Formatter.
+func (o *Option) sinkFormatter(formatter string) string {
+	if formatter == "" {
+		return o.Formatter
+	}
+	return formatter
+}
+
+// consoleWriter returns the destination of the console sink, os.Stdout unless
+// WithConsoleWriter was given.
+func (o *Option) consoleWriter() io.Writer {
+	if o.Console.Writer == nil {
+		return os.Stdout
+	}
+	return o.Console.Writer
+}
+
+func WithLevel[T logr.LevelValue](level T) FnOption {
+	return func(option *Option) {
+		option.Level = fmt.Sprint(level)
+	}
+}
+
+func WithFormatter[T logr.FormatValue](formatter T) FnOption {
+	return func(option *Option) {
+		option.Formatter = fmt.Sprint(formatter)
+	}
+}
+
+// async wraps the destination of a sink in a logr.AsyncWriter when WithAsync
+// was given.
+func (o *Option) async(w io.Writer) io.Writer {
+	if o.Async.BufferSize <= 0 {
+		return w
+	}
+	return logr.NewAsyncWriter(w, o.Async.BufferSize, o.Async.Policy)
+}
+
+// validate reports the levels and formats New would replace by INFO and JSON,
+// and sampling without an interval.
+func (o *Option) validate() error {
+	return errors.Join(
+		config.ValidateLevel("level", o.Level),
+		config.ValidateFormat("formatter", o.Formatter),
+		config.ValidateLevel("console level", o.Console.Level),
+		config.ValidateFormat("console formatter", o.Console.Formatter),
+		config.ValidateLevel("file level", o.File.Level),
+		config.ValidateFormat("file formatter", o.File.Formatter),
+		config.ValidateSampling(o.Sampling.Initial, o.Sampling.Thereafter, o.Sampling.Interval),
+	)
+}
+
+func WithConsoleLevel[T logr.LevelValue](level T) FnOption {
+	return func(option *Option) {
+		option.Console.Level = fmt.Sprint(level)
+	}
+}
+
+func WithFileLevel[T logr.LevelValue](level T) FnOption {
+	return func(option *Option) {
+		option.File.Level = fmt.Sprint(level)
+	}
 }
 
-func WithLevel(level string) FnOption {
+func WithConsoleFormatter[T logr.FormatValue](formatter T) FnOption {
 	return func(option *Option) {
-		option.Level = level
+		option.Console.Formatter = fmt.Sprint(formatter)
 	}
 }
 
-func WithFormatter(formatter string) FnOption {
+func WithFileFormatter[T logr.FormatValue](formatter T) FnOption {
 	return func(option *Option) {
-		option.Formatter = formatter
+		option.File.Formatter = fmt.Sprint(formatter)
 	}
 }
 
@@ -42,6 +151,13 @@ func WithConsole(enabled bool) FnOption {
 	}
 }
 
+// WithConsoleWriter sends the console sink to w instead of os.Stdout.
+func WithConsoleWriter(w io.Writer) FnOption {
+	return func(option *Option) {
+		option.Console.Writer = w
+	}
+}
+
 func WithConsoleApplyColor(applyColor bool) FnOption {
 	return func(option *Option) {
 		option.Console.ApplyColor = applyColor
@@ -63,3 +179,51 @@ func WithFileRotation(maxSize int, maxAge int, compress bool) FnOption {
 		option.File.Compress = compress
 	}
 }
+
+// WithAddSource reports the file and line of the logging call; it is enabled
+// by default.
+func WithAddSource(addSource bool) FnOption {
+	return func(option *Option) {
+		option.AddSource = addSource
+	}
+}
+
+// WithContextExtractor adds an extractor whose fields are merged into every
+// record logged through the *Context methods and FromContext.
+func WithContextExtractor(extractor logr.ContextExtractor) FnOption {
+	return func(option *Option) {
+		option.ContextExtractors = append(option.ContextExtractors, extractor)
+	}
+}
+
+// WithOnFatal replaces logr.Exit(1) as what Fatal does once its record is
+// written and the sinks are flushed. Tests use it to keep the process alive;
+// when fn returns, so does Fatal.
+func WithOnFatal(fn func()) FnOption {
+	return func(option *Option) {
+		option.OnFatal = fn
+	}
+}
+
+// WithAsync writes the records of every sink from a background goroutine,
+// queueing up to bufferSize of them; policy says what happens when the queue
+// is full. Close drains the queue.
+func WithAsync(bufferSize int, policy logr.OverflowPolicy) FnOption {
+	return func(option *Option) {
+		option.Async.BufferSize = bufferSize
+		option.Async.Policy = policy
+	}
+}
+
+// WithSampling keeps, in each interval, the first initial records with the
+// same level and message and then one of every thereafter. Once per interval
+// a record with logr.SamplingMessage tells how many were suppressed. Unlike
+// zerolog.BurstSampler, each message is counted apart and Panic and Fatal
+// events are never dropped; see the README.
+func WithSampling(initial, thereafter int, interval time.Duration) FnOption {
+	return func(option *Option) {
+		option.Sampling.Initial = initial
+		option.Sampling.Thereafter = thereafter
+		option.Sampling.Interval = interval
+	}
+}
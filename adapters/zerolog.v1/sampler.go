@@ -0,0 +1,21 @@
+package zerolog
+
+import (
+	"github.com/rs/zerolog"
+
+	"github.com/BrunoTulio/logr"
+)
+
+// samplingHook discards the events its sampler does not keep. Unlike a
+// zerolog.Sampler, a hook sees the message, so records are told apart by
+// level and message as in the other adapters.
+type samplingHook struct {
+	sampler *logr.Sampler
+}
+
+// Run implements zerolog.Hook.
+func (h samplingHook) Run(e *zerolog.Event, level zerolog.Level, message string) {
+	if !h.sampler.Sample(fromZerologLevel(level), message) {
+		e.Discard()
+	}
+}
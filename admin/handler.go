@@ -0,0 +1,195 @@
+// Package admin exposes an HTTP handler to inspect and change the levels of a
+// running logr.Logger.
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+)
+
+// DefaultSink names the single level of loggers that do not implement
+// SinkLeveler.
+const DefaultSink = "default"
+
+// ErrInvalidLevel is returned for names logr.ParseLevel does not accept.
+var ErrInvalidLevel = logr.ErrInvalidLevel
+
+// SinkLeveler is implemented by loggers that keep one level per sink, such as
+// the built-in adapters. A nil map, returned by decorators such as
+// logr.WithRateLimit over a logger with a single level, counts as not
+// implementing it.
+type SinkLeveler interface {
+	SinkLevels() map[string]*logr.AtomicLevel
+}
+
+// leveler is satisfied by both *logr.AtomicLevel and logr.Logger.
+type leveler interface {
+	Level() logr.Level
+	SetLevel(level logr.Level)
+}
+
+type (
+	// Handler serves the levels of a logger:
+	//
+	//	GET        returns the current level of every sink
+	//	PUT, POST  sets the level of one sink (or all of them) and, when ttl is
+	//	           given, reverts it to the previous level once ttl elapses
+	Handler struct {
+		logger  logr.Logger
+		mu      sync.Mutex
+		reverts map[string]*time.Timer
+	}
+
+	// Request is the body accepted by PUT and POST.
+	Request struct {
+		Level string `json:"level"`
+		Sink  string `json:"sink,omitempty"`
+		TTL   string `json:"ttl,omitempty"`
+	}
+
+	// Response is the body returned by every successful request.
+	Response struct {
+		Levels map[string]string `json:"levels"`
+	}
+
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+var _ http.Handler = (*Handler)(nil)
+
+func NewHandler(logger logr.Logger) *Handler {
+	return &Handler{
+		logger:  logger,
+		reverts: make(map[string]*time.Timer),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeJSON(w, http.StatusOK, h.response())
+	case http.MethodPut, http.MethodPost:
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %w", err))
+			return
+		}
+		if err := h.apply(req); err != nil {
+			h.writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		h.writeJSON(w, http.StatusOK, h.response())
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut, http.MethodPost}, ", "))
+		h.writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+func (h *Handler) apply(req Request) error {
+	level, err := logr.ParseLevel(req.Level)
+	if err != nil {
+		return err
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid ttl %q", req.TTL)
+		}
+	}
+
+	targets := h.levels()
+	if req.Sink != "" {
+		target, ok := targets[req.Sink]
+		if !ok {
+			return fmt.Errorf("unknown sink %q", req.Sink)
+		}
+		targets = map[string]leveler{req.Sink: target}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sink, target := range targets {
+		// Um novo ajuste cancela a reversão pendente e passa a ser a referência
+		if timer, ok := h.reverts[sink]; ok {
+			timer.Stop()
+			delete(h.reverts, sink)
+		}
+
+		previous := target.Level()
+		target.SetLevel(level)
+
+		if ttl > 0 {
+			h.reverts[sink] = h.scheduleRevert(sink, previous, ttl)
+		}
+	}
+	return nil
+}
+
+// scheduleRevert sets sink back to previous once ttl elapses. The level is
+// looked up again then, since a Reload of the logger replaces the levels of
+// its sinks.
+func (h *Handler) scheduleRevert(sink string, previous logr.Level, ttl time.Duration) *time.Timer {
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if h.reverts[sink] != timer {
+			return
+		}
+		delete(h.reverts, sink)
+		if target, ok := h.levels()[sink]; ok {
+			target.SetLevel(previous)
+		}
+	})
+	return timer
+}
+
+// levels returns the levels managed by the handler. Loggers that do not
+// implement SinkLeveler are exposed as a single DefaultSink.
+func (h *Handler) levels() map[string]leveler {
+	sl, ok := h.logger.(SinkLeveler)
+	var sinkLevels map[string]*logr.AtomicLevel
+	if ok {
+		sinkLevels = sl.SinkLevels()
+	}
+	if sinkLevels == nil {
+		return map[string]leveler{DefaultSink: h.logger}
+	}
+
+	levels := make(map[string]leveler, len(sinkLevels))
+	for sink, level := range sinkLevels {
+		levels[sink] = level
+	}
+	return levels
+}
+
+func (h *Handler) response() Response {
+	levels := h.levels()
+	resp := Response{Levels: make(map[string]string, len(levels))}
+	for sink, level := range levels {
+		resp.Levels[sink] = level.Level().String()
+	}
+	return resp
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, status int, err error) {
+	h.writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,122 @@
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/adapters/native"
+	"github.com/BrunoTulio/logr/admin"
+	"github.com/BrunoTulio/logr/config"
+)
+
+func serve(t *testing.T, h http.Handler, method, body string) (int, admin.Response) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, "/log/level", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp admin.Response
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	return rec.Code, resp
+}
+
+func TestHandler(t *testing.T) {
+	logger := native.New(
+		native.WithConsole(true),
+		native.WithConsoleLevel("INFO"),
+		native.WithFile(true, t.TempDir(), "app.log"),
+		native.WithFileLevel("WARN"),
+	)
+	h := admin.NewHandler(logger)
+
+	code, resp := serve(t, h, http.MethodGet, "")
+	if code != http.StatusOK || resp.Levels["console"] != "INFO" || resp.Levels["file"] != "WARN" {
+		t.Fatalf("GET = %d %v", code, resp.Levels)
+	}
+
+	code, resp = serve(t, h, http.MethodPut, `{"level":"debug","sink":"console","ttl":"50ms"}`)
+	if code != http.StatusOK || resp.Levels["console"] != "DEBUG" || resp.Levels["file"] != "WARN" {
+		t.Fatalf("PUT = %d %v", code, resp.Levels)
+	}
+	if logger.Level() != logr.LevelDebug {
+		t.Fatalf("logger level = %v, want %v", logger.Level(), logr.LevelDebug)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for logger.Level() != logr.LevelInfo {
+		if time.Now().After(deadline) {
+			t.Fatal("level was not reverted after ttl")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for _, body := range []string{`{"level":"verbose"}`, `{"level":"INFO","sink":"syslog"}`, `{"level":"INFO","ttl":"soon"}`, `{`} {
+		if code, _ = serve(t, h, http.MethodPost, body); code != http.StatusBadRequest {
+			t.Errorf("POST %s = %d, want %d", body, code, http.StatusBadRequest)
+		}
+	}
+
+	if code, _ = serve(t, h, http.MethodDelete, ""); code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE = %d, want %d", code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandlerWithoutSinks(t *testing.T) {
+	h := admin.NewHandler(logr.Noop{})
+
+	code, resp := serve(t, h, http.MethodGet, "")
+	if code != http.StatusOK || resp.Levels[admin.DefaultSink] != "INFO" {
+		t.Fatalf("GET = %d %v", code, resp.Levels)
+	}
+}
+
+func TestHandlerThroughDecorator(t *testing.T) {
+	limit := logr.WithRateLimit(t.Name(), time.Minute)
+	logger := limit(native.New(native.WithConsole(true), native.WithFile(true, t.TempDir(), "app.log"), native.WithFileLevel("WARN")))
+
+	code, resp := serve(t, admin.NewHandler(logger), http.MethodGet, "")
+	if code != http.StatusOK || resp.Levels["console"] != "INFO" || resp.Levels["file"] != "WARN" {
+		t.Fatalf("GET = %d %v, want the sinks of the decorated logger", code, resp.Levels)
+	}
+
+	code, resp = serve(t, admin.NewHandler(limit(logr.Noop{})), http.MethodGet, "")
+	if code != http.StatusOK || resp.Levels[admin.DefaultSink] != "INFO" {
+		t.Fatalf("GET = %d %v, want the %s sink", code, resp.Levels, admin.DefaultSink)
+	}
+}
+
+func TestHandlerRevertAfterReload(t *testing.T) {
+	logger := native.New(native.WithConsole(true), native.WithConsoleWriter(io.Discard))
+	h := admin.NewHandler(logger)
+
+	if code, _ := serve(t, h, http.MethodPut, `{"level":"debug","sink":"console","ttl":"50ms"}`); code != http.StatusOK {
+		t.Fatalf("PUT = %d", code)
+	}
+	// o Reload troca os níveis dos sinks antes de a reversão disparar
+	if err := logger.(config.Reloader).Reload(config.Default()); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		_, resp := serve(t, h, http.MethodGet, "")
+		if resp.Levels["console"] == "INFO" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("console level = %s after the ttl, want INFO", resp.Levels["console"])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,220 @@
+package logr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy is what an AsyncWriter does with a record when its queue is
+// full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for room in the queue, so no record is lost.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest discards the record being written.
+	OverflowDropNewest
+	// OverflowDropOldest discards the oldest queued record to make room.
+	OverflowDropOldest
+)
+
+var overflowPolicyNames = []string{"BLOCK", "DROP_NEWEST", "DROP_OLDEST"}
+
+// String returns the name of p, as in "DROP_OLDEST".
+func (p OverflowPolicy) String() string {
+	if p >= 0 && int(p) < len(overflowPolicyNames) {
+		return overflowPolicyNames[p]
+	}
+	return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+}
+
+// asyncDropped counts the records dropped by every AsyncWriter.
+var asyncDropped atomic.Uint64
+
+// AsyncDropped returns how many records every AsyncWriter of the process has
+// dropped so far.
+func AsyncDropped() uint64 {
+	return asyncDropped.Load()
+}
+
+// DropCounter is implemented by the loggers of the built-in adapters, which
+// report per sink what AsyncDropped sums over the whole process.
+type DropCounter interface {
+	// AsyncDropped returns how many records each sink written through an
+	// AsyncWriter has dropped so far, keyed by sink name.
+	AsyncDropped() map[string]uint64
+}
+
+var _ io.WriteCloser = (*AsyncWriter)(nil)
+
+// AsyncWriter queues writes to be made by a background goroutine, so a slow
+// destination such as a stalled disk does not hold up the goroutines that
+// log. Write copies the record and returns at once, unless the queue is full
+// and the policy is OverflowBlock. Errors from the destination are returned
+// by the next Sync or Close.
+//
+// Close drains the queue before returning; writes made after it go straight
+// to the destination.
+type AsyncWriter struct {
+	out     io.Writer
+	policy  OverflowPolicy
+	queue   chan []byte
+	flushes chan chan struct{}
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	// mu impede Close de fechar a fila enquanto Write envia nela
+	mu     sync.RWMutex
+	closed bool
+
+	errMu sync.Mutex
+	err   error
+}
+
+// NewAsyncWriter starts the goroutine that writes to out the records queued,
+// at most bufferSize at a time.
+func NewAsyncWriter(out io.Writer, bufferSize int, policy OverflowPolicy) *AsyncWriter {
+	w := &AsyncWriter{
+		out:     out,
+		policy:  policy,
+		queue:   make(chan []byte, max(bufferSize, 1)),
+		flushes: make(chan chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write implements io.Writer.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return w.out.Write(p)
+	}
+
+	// os encoders reaproveitam o buffer depois que Write retorna
+	record := append([]byte(nil), p...)
+	switch w.policy {
+	case OverflowDropNewest:
+		select {
+		case w.queue <- record:
+		default:
+			w.drop()
+		}
+	case OverflowDropOldest:
+		for sent := false; !sent; {
+			select {
+			case w.queue <- record:
+				sent = true
+			default:
+				select {
+				case <-w.queue:
+					w.drop()
+				default:
+				}
+			}
+		}
+	default:
+		w.queue <- record
+	}
+	return len(p), nil
+}
+
+// Dropped returns how many records w has dropped so far.
+func (w *AsyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Sync waits until the records queued before it are written and then syncs
+// the destination.
+func (w *AsyncWriter) Sync() error {
+	w.mu.RLock()
+	if !w.closed {
+		ack := make(chan struct{})
+		w.flushes <- ack
+		w.mu.RUnlock()
+		<-ack
+	} else {
+		w.mu.RUnlock()
+	}
+	return errors.Join(w.takeErr(), SyncWriter(w.out))
+}
+
+// Close writes every queued record, stops the goroutine and syncs the
+// destination. The destination itself is left open.
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+	return errors.Join(w.takeErr(), SyncWriter(w.out))
+}
+
+// Unwrap returns the destination of w.
+func (w *AsyncWriter) Unwrap() io.Writer {
+	return w.out
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+	for {
+		select {
+		case record, ok := <-w.queue:
+			if !ok {
+				return
+			}
+			w.write(record)
+		case ack := <-w.flushes:
+			w.drain(len(w.queue))
+			close(ack)
+		}
+	}
+}
+
+// drain writes up to n queued records, stopping early if OverflowDropOldest
+// took some of them.
+func (w *AsyncWriter) drain(n int) {
+	for ; n > 0; n-- {
+		select {
+		case record, ok := <-w.queue:
+			if !ok {
+				return
+			}
+			w.write(record)
+		default:
+			return
+		}
+	}
+}
+
+func (w *AsyncWriter) write(record []byte) {
+	if _, err := w.out.Write(record); err != nil {
+		w.errMu.Lock()
+		w.err = err
+		w.errMu.Unlock()
+	}
+}
+
+func (w *AsyncWriter) drop() {
+	w.dropped.Add(1)
+	asyncDropped.Add(1)
+}
+
+func (w *AsyncWriter) takeErr() error {
+	w.errMu.Lock()
+	defer w.errMu.Unlock()
+	err := w.err
+	w.err = nil
+	return err
+}
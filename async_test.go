@@ -0,0 +1,96 @@
+package logr_test
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+)
+
+// gateWriter blocks every write until open is closed, and reports on
+// entered the first write.
+type gateWriter struct {
+	open    chan struct{}
+	entered chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.entered) })
+	<-w.open
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gateWriter) lines() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return strings.Fields(w.buf.String())
+}
+
+func TestAsyncWriterPolicies(t *testing.T) {
+	tests := []struct {
+		policy      logr.OverflowPolicy
+		wantDropped uint64
+		want        []string
+	}{
+		// o primeiro registro sai da fila e fica preso no Write do destino
+		{logr.OverflowDropNewest, 2, []string{"0", "1", "2"}},
+		{logr.OverflowDropOldest, 2, []string{"0", "3", "4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			out := &gateWriter{open: make(chan struct{}), entered: make(chan struct{})}
+			w := logr.NewAsyncWriter(out, 2, tt.policy)
+
+			fmt.Fprintln(w, "0")
+			<-out.entered
+			for i := 1; i < 5; i++ {
+				fmt.Fprintln(w, i)
+			}
+			close(out.open)
+
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := w.Dropped(); got != tt.wantDropped {
+				t.Errorf("Dropped() = %d, want %d", got, tt.wantDropped)
+			}
+			if got := out.lines(); !slices.Equal(got, tt.want) {
+				t.Errorf("written %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsyncWriterBlock(t *testing.T) {
+	var out bytes.Buffer
+	w := logr.NewAsyncWriter(&out, 1, logr.OverflowBlock)
+
+	for i := range 100 {
+		fmt.Fprintln(w, i)
+	}
+	if err := w.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(strings.Fields(out.String())); got != 100 {
+		t.Errorf("Sync left %d of 100 records written", got)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// depois de Close, Write vai direto ao destino
+	fmt.Fprintln(w, "late")
+	if !strings.HasSuffix(out.String(), "late\n") {
+		t.Errorf("write after Close was not written")
+	}
+}
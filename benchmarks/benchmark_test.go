@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/adapters/native"
 	"github.com/BrunoTulio/logr/adapters/slog.v1"
 	"github.com/BrunoTulio/logr/adapters/zap.v1"
 )
@@ -31,6 +32,17 @@ func BenchmarkZap_SimpleLog(b *testing.B) {
 	}
 }
 
+func BenchmarkNative_SimpleLog(b *testing.B) {
+	logger := native.New(
+		native.WithConsole(false),
+	)
+
+	b.ResetTimer()
+	for range b.N {
+		logger.Info("Simple log message")
+	}
+}
+
 // Benchmark com campos estruturados.
 func BenchmarkSlog_WithFields(b *testing.B) {
 	logger := slog.New(
@@ -62,6 +74,21 @@ func BenchmarkZap_WithFields(b *testing.B) {
 	}
 }
 
+func BenchmarkNative_WithFields(b *testing.B) {
+	logger := native.New(
+		native.WithConsole(false),
+	)
+
+	b.ResetTimer()
+	for i := range b.N {
+		logger.WithFields(
+			logr.String("user_id", "12345"),
+			logr.Bool("active", true),
+			logr.Int("count", i),
+		).Info("Log with fields")
+	}
+}
+
 // Benchmark com campos agrupados.
 func BenchmarkSlog_WithGroupedFields(b *testing.B) {
 	logger := slog.New(
@@ -137,6 +164,15 @@ func BenchmarkLoggerCreation_Zap(b *testing.B) {
 	}
 }
 
+func BenchmarkLoggerCreation_Native(b *testing.B) {
+	b.ResetTimer()
+	for range b.N {
+		_ = native.New(
+			native.WithConsole(false),
+		)
+	}
+}
+
 // Benchmark de formatação JSON vs TEXT.
 func BenchmarkSlog_JSONFormat(b *testing.B) {
 	logger := slog.New(
@@ -0,0 +1,195 @@
+// Package config is the adapter independent description of a logger: which
+// sinks are enabled, their levels and formats, file rotation, caller
+// reporting and sampling. A Config is decoded from JSON, a map or environment
+// variables and given to the NewFromConfig constructor of any adapter.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+)
+
+var (
+	// Levels are the canonical values of Console.Level and File.Level; any
+	// name accepted by logr.ParseLevel is read as one of them.
+	Levels = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "PANIC", "FATAL"}
+	// Formats are the canonical values of Console.Format and File.Format.
+	Formats = []string{"TEXT", "JSON"}
+)
+
+type (
+	Config struct {
+		Console Console `json:"console"`
+		File    File    `json:"file"`
+		// Caller adds the file and line of the logging call to every record.
+		Caller   bool     `json:"caller"`
+		Sampling Sampling `json:"sampling"`
+	}
+
+	Console struct {
+		Enabled bool   `json:"enabled"`
+		Level   string `json:"level"`
+		Format  string `json:"format"`
+	}
+
+	File struct {
+		Enabled bool   `json:"enabled"`
+		Level   string `json:"level"`
+		Format  string `json:"format"`
+		Path    string `json:"path"`
+		Name    string `json:"name"`
+		// MaxSize is the size in megabytes that triggers a rotation.
+		MaxSize int `json:"max_size"`
+		// MaxAge is the number of days rotated files are kept.
+		MaxAge   int  `json:"max_age"`
+		Compress bool `json:"compress"`
+	}
+
+	// Sampling keeps the first Initial records with the same level and
+	// message in each Interval, and then one of every Thereafter. A zero
+	// Initial disables sampling.
+	Sampling struct {
+		Initial    int      `json:"initial"`
+		Thereafter int      `json:"thereafter"`
+		Interval   Duration `json:"interval"`
+	}
+
+	// Duration is a time.Duration written in JSON as a string such as "1s".
+	Duration time.Duration
+)
+
+// Default is the configuration used when nothing is given: INFO records in
+// TEXT on the console.
+func Default() Config {
+	return Config{
+		Console: Console{Enabled: true, Level: "INFO", Format: "TEXT"},
+		File:    File{Level: "INFO", Format: "JSON"},
+	}
+}
+
+// Parse decodes a JSON configuration over Default. Unknown keys are errors, so
+// a misspelled option is not silently ignored.
+func Parse(data []byte) (Config, error) {
+	cfg := Default()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("config: %w", err)
+	}
+	cfg.normalize()
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Load reads and parses the JSON configuration file at name.
+func Load(name string) (Config, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return Config{}, fmt.Errorf("config: %w", err)
+	}
+	return Parse(data)
+}
+
+// FromMap decodes a configuration with the same keys as the JSON form, as in
+// {"console": {"level": "DEBUG"}}.
+func FromMap(m map[string]any) (Config, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return Config{}, fmt.Errorf("config: %w", err)
+	}
+	return Parse(data)
+}
+
+// Validate reports every invalid value of c at once.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("config: "+format, args...))
+		}
+	}
+
+	check(validLevel(c.Console.Level), "console.level %q is not one of %s", c.Console.Level, strings.Join(Levels, ", "))
+	check(validFormat(c.Console.Format), "console.format %q is not one of %s", c.Console.Format, strings.Join(Formats, ", "))
+	check(validLevel(c.File.Level), "file.level %q is not one of %s", c.File.Level, strings.Join(Levels, ", "))
+	check(validFormat(c.File.Format), "file.format %q is not one of %s", c.File.Format, strings.Join(Formats, ", "))
+	check(!c.File.Enabled || c.File.Name != "", "file.name is required when the file sink is enabled")
+	check(c.File.MaxSize >= 0, "file.max_size must not be negative, got %d", c.File.MaxSize)
+	check(c.File.MaxAge >= 0, "file.max_age must not be negative, got %d", c.File.MaxAge)
+	check(c.Sampling.Initial >= 0, "sampling.initial must not be negative, got %d", c.Sampling.Initial)
+	check(c.Sampling.Thereafter >= 0, "sampling.thereafter must not be negative, got %d", c.Sampling.Thereafter)
+	check(c.Sampling.Initial == 0 || c.Sampling.Interval > 0, "sampling.interval is required when sampling is enabled")
+
+	return errors.Join(errs...)
+}
+
+// normalize replaces the levels and formats accepted by logr.ParseLevel and
+// logr.ParseFormat, such as "warning", by their canonical names.
+func (c *Config) normalize() {
+	c.Console.Level = normalizeLevel(c.Console.Level)
+	c.Console.Format = normalizeFormat(c.Console.Format)
+	c.File.Level = normalizeLevel(c.File.Level)
+	c.File.Format = normalizeFormat(c.File.Format)
+}
+
+func normalizeLevel(level string) string {
+	if parsed, err := logr.ParseLevel(level); err == nil {
+		return parsed.String()
+	}
+	return level
+}
+
+func normalizeFormat(format string) string {
+	if parsed, err := logr.ParseFormat(format); err == nil {
+		return parsed.String()
+	}
+	return format
+}
+
+// validLevel and validFormat accept the empty string, which the adapters read
+// as their default.
+func validLevel(level string) bool {
+	_, err := logr.ParseLevel(level)
+	return level == "" || err == nil
+}
+
+func validFormat(format string) bool {
+	_, err := logr.ParseFormat(format)
+	return format == "" || err == nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Besides strings such as "500ms",
+// a number is read as nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch value := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+	case float64:
+		*d = Duration(value)
+	default:
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package config_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr/config"
+)
+
+func TestParse(t *testing.T) {
+	cfg, err := config.Parse([]byte(`{
+		"console": {"level": "debug"},
+		"file": {"enabled": true, "path": "logs", "name": "app.log", "max_size": 10, "format": "text"},
+		"caller": true,
+		"sampling": {"initial": 100, "thereafter": 10, "interval": "1s"}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := config.Default()
+	want.Console.Level = "DEBUG"
+	want.File = config.File{Enabled: true, Level: "INFO", Format: "TEXT", Path: "logs", Name: "app.log", MaxSize: 10}
+	want.Caller = true
+	want.Sampling = config.Sampling{Initial: 100, Thereafter: 10, Interval: config.Duration(time.Second)}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Parse = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"unknown key", `{"console": {"levle": "INFO"}}`, []string{`unknown field "levle"`}},
+		{"invalid values", `{"console": {"level": "VERBOSE", "format": "XML"}, "file": {"enabled": true}}`, []string{
+			`console.level "VERBOSE"`,
+			`console.format "XML"`,
+			"file.name is required",
+		}},
+		{"sampling without interval", `{"sampling": {"initial": 5}}`, []string{"sampling.interval is required"}},
+		{"invalid duration", `{"sampling": {"interval": "soon"}}`, []string{"invalid duration"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.Parse([]byte(tt.input))
+			if err == nil {
+				t.Fatal("Parse succeeded")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	cfg, err := config.FromMap(map[string]any{
+		"console": map[string]any{"enabled": false},
+		"file":    map[string]any{"enabled": true, "name": "app.log", "level": "WARN"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Console.Enabled || !cfg.File.Enabled || cfg.File.Level != "WARN" || cfg.Console.Format != "TEXT" {
+		t.Errorf("FromMap = %+v", cfg)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("APP_LOG_LEVEL", "warn")
+	t.Setenv("APP_LOG_CONSOLE_FORMAT", "JSON")
+	t.Setenv("APP_LOG_FILE_NAME", "app.log")
+	t.Setenv("APP_LOG_FILE_MAX_SIZE", "50")
+	t.Setenv("APP_LOG_SAMPLING_INTERVAL", "2s")
+
+	cfg, err := config.FromEnv("APP_LOG")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := config.Default()
+	want.Console = config.Console{Enabled: true, Level: "WARN", Format: "JSON"}
+	want.File = config.File{Enabled: true, Level: "WARN", Format: "JSON", Name: "app.log", MaxSize: 50}
+	want.Sampling.Interval = config.Duration(2 * time.Second)
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("FromEnv = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestFromEnvErrors(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "LOUD")
+	t.Setenv("LOG_FILE_MAX_AGE", "a week")
+	t.Setenv("LOG_CALLER", "sometimes")
+
+	_, err := config.FromEnv("LOG")
+	if err == nil {
+		t.Fatal("FromEnv succeeded")
+	}
+	for _, want := range []string{`LOG_FILE_MAX_AGE="a week"`, `LOG_CALLER="sometimes"`, `console.level "LOUD"`, `file.level "LOUD"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
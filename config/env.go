@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// FromEnv builds a configuration over Default from the environment variables
+// named with prefix, as in LOG_LEVEL for the prefix "LOG":
+//
+//	LOG_LEVEL, LOG_FORMAT                  level and format of every sink
+//	LOG_CONSOLE                            console sink enabled (bool)
+//	LOG_CONSOLE_LEVEL, LOG_CONSOLE_FORMAT
+//	LOG_FILE                               file sink enabled (bool); set by default
+//	                                       when LOG_FILE_PATH or LOG_FILE_NAME is
+//	LOG_FILE_LEVEL, LOG_FILE_FORMAT
+//	LOG_FILE_PATH, LOG_FILE_NAME
+//	LOG_FILE_MAX_SIZE, LOG_FILE_MAX_AGE    megabytes and days
+//	LOG_FILE_COMPRESS                      bool
+//	LOG_CALLER                             bool
+//	LOG_SAMPLING_INITIAL, LOG_SAMPLING_THEREAFTER
+//	LOG_SAMPLING_INTERVAL                  duration, as in "1s"
+//
+// Unset variables keep the default; malformed ones are reported together with
+// the errors of Validate.
+func FromEnv(prefix string) (Config, error) {
+	e := env{prefix: prefix}
+	cfg := Default()
+
+	e.string("LEVEL", &cfg.Console.Level)
+	e.string("LEVEL", &cfg.File.Level)
+	e.string("FORMAT", &cfg.Console.Format)
+	e.string("FORMAT", &cfg.File.Format)
+
+	e.bool("CONSOLE", &cfg.Console.Enabled)
+	e.string("CONSOLE_LEVEL", &cfg.Console.Level)
+	e.string("CONSOLE_FORMAT", &cfg.Console.Format)
+
+	_, hasPath := e.lookup("FILE_PATH")
+	_, hasName := e.lookup("FILE_NAME")
+	cfg.File.Enabled = hasPath || hasName
+	e.bool("FILE", &cfg.File.Enabled)
+	e.string("FILE_LEVEL", &cfg.File.Level)
+	e.string("FILE_FORMAT", &cfg.File.Format)
+	e.string("FILE_PATH", &cfg.File.Path)
+	e.string("FILE_NAME", &cfg.File.Name)
+	e.int("FILE_MAX_SIZE", &cfg.File.MaxSize)
+	e.int("FILE_MAX_AGE", &cfg.File.MaxAge)
+	e.bool("FILE_COMPRESS", &cfg.File.Compress)
+
+	e.bool("CALLER", &cfg.Caller)
+	e.int("SAMPLING_INITIAL", &cfg.Sampling.Initial)
+	e.int("SAMPLING_THEREAFTER", &cfg.Sampling.Thereafter)
+	e.duration("SAMPLING_INTERVAL", &cfg.Sampling.Interval)
+
+	cfg.normalize()
+	if err := errors.Join(errors.Join(e.errs...), cfg.Validate()); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// env reads the variables of one prefix, collecting parse errors.
+type env struct {
+	prefix string
+	errs   []error
+}
+
+func (e *env) name(key string) string {
+	if e.prefix == "" {
+		return key
+	}
+	return e.prefix + "_" + key
+}
+
+func (e *env) lookup(key string) (string, bool) {
+	value, ok := os.LookupEnv(e.name(key))
+	return value, ok && value != ""
+}
+
+func (e *env) string(key string, dst *string) {
+	if value, ok := e.lookup(key); ok {
+		*dst = value
+	}
+}
+
+func (e *env) bool(key string, dst *bool) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("config: %s=%q is not a boolean", e.name(key), value))
+		return
+	}
+	*dst = parsed
+}
+
+func (e *env) int(key string, dst *int) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("config: %s=%q is not an integer", e.name(key), value))
+		return
+	}
+	*dst = parsed
+}
+
+func (e *env) duration(key string, dst *Duration) {
+	value, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("config: %s=%q is not a duration", e.name(key), value))
+		return
+	}
+	*dst = Duration(parsed)
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/BrunoTulio/logr"
+)
+
+type (
+	// Adapter holds what NewFromConfig, NewFromFile and FromEnv need from an
+	// adapter: its constructor and the translation of a Config into its
+	// options. The adapters expose these methods as their own functions.
+	Adapter[F any] struct {
+		New     func(fns ...F) logr.Logger
+		Options func(cfg Config) []F
+	}
+
+	// Backend is the part of an adapter's logger that Reload rebuilds: the
+	// sinks, by name, and what Close releases.
+	Backend interface {
+		Sinks() map[string]Sink
+		Close() error
+	}
+
+	// Sink is one destination of a Backend.
+	Sink struct {
+		// Level filters the records of the sink.
+		Level *logr.AtomicLevel
+		// Configured is the level the sink was created with. Level differs
+		// from it after SetLevel or a change through the admin handler.
+		Configured logr.Level
+		// Writer is what the sink's encoder writes to.
+		Writer *SwitchWriter
+
+		// async é o AsyncWriter do sink, nil sem WithAsync
+		async *logr.AsyncWriter
+		// carried soma os descartes dos sinks que este substituiu
+		carried *atomic.Uint64
+	}
+
+	// SwitchWriter is the writer of a Sink. Reload switches the sinks it
+	// replaces to their successors, so a record still being written through
+	// the previous backend ends up in the new one instead of in a file that
+	// was already closed.
+	SwitchWriter struct {
+		mu sync.RWMutex
+		w  io.Writer
+	}
+)
+
+// NewFromConfig validates cfg and creates the logger it describes. fns are
+// applied after the config, for settings such as WithConsoleWriter that it
+// does not cover.
+func (a Adapter[F]) NewFromConfig(cfg Config, fns ...F) (logr.Logger, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return a.New(append(a.Options(cfg), fns...)...), nil
+}
+
+// NewFromFile creates the logger described by the JSON config file at name and
+// reloads it whenever the file changes or the process receives SIGHUP, until
+// ctx is done. A failed reload is logged and the previous config is kept.
+func (a Adapter[F]) NewFromFile(ctx context.Context, name string, fns ...F) (logr.Logger, error) {
+	cfg, err := Load(name)
+	if err != nil {
+		return nil, err
+	}
+	l, err := a.NewFromConfig(cfg, fns...)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := l.(Reloader)
+	if !ok {
+		return nil, errors.New("config: the logger does not implement Reloader")
+	}
+	go Watch(ctx, name, DefaultWatchInterval, r, func(err error) {
+		l.Errorw("config reload failed", logr.Err(err))
+	})
+	return l, nil
+}
+
+// FromEnv returns the options described by the environment variables named
+// with prefix (see FromEnv).
+func (a Adapter[F]) FromEnv(prefix string) ([]F, error) {
+	cfg, err := FromEnv(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return a.Options(cfg), nil
+}
+
+// NewSink returns a Sink writing to w at level.
+func NewSink(w io.Writer, level logr.Level) Sink {
+	async, _ := w.(*logr.AsyncWriter)
+	return Sink{
+		Level:      logr.NewAtomicLevel(level),
+		Configured: level,
+		Writer:     &SwitchWriter{w: w},
+		async:      async,
+		carried:    &atomic.Uint64{},
+	}
+}
+
+// Async reports whether the sink writes through a logr.AsyncWriter.
+func (s Sink) Async() bool {
+	return s.async != nil
+}
+
+// Dropped returns how many records the sink has dropped because the queue of
+// its logr.AsyncWriter was full, counting those dropped by the sinks with the
+// same name that Reload replaced. It is 0 for a sink written synchronously.
+func (s Sink) Dropped() uint64 {
+	dropped := s.carried.Load()
+	if s.async != nil {
+		dropped += s.async.Dropped()
+	}
+	return dropped
+}
+
+// Reload validates cfg and replaces the backend in root with the one build
+// creates from the current one. A sink whose configured level cfg leaves
+// unchanged keeps the level it has now, so changes made at run time survive
+// the reload. The previous backend is closed once the records being written
+// through it are done; those that had not reached its sinks yet go to the
+// sinks with the same name in the new backend, which also take over the
+// counts of Dropped.
+func Reload[T any, B interface {
+	*T
+	Backend
+}](root *atomic.Pointer[T], cfg Config, build func(current B) B) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	current := B(root.Load())
+	next := build(current)
+	nextSinks := next.Sinks()
+	for name, sink := range current.Sinks() {
+		if n, ok := nextSinks[name]; ok && n.Configured == sink.Configured {
+			n.Level.SetLevel(sink.Level.Level())
+		}
+	}
+
+	previous := B(root.Swap(next))
+	for name, sink := range previous.Sinks() {
+		var successor io.Writer = io.Discard
+		n, ok := nextSinks[name]
+		if ok {
+			successor = n.Writer
+		}
+		sink.Writer.Switch(successor)
+		// depois do Switch o sink anterior não descarta mais nada
+		if ok {
+			n.carried.Add(sink.Dropped())
+		}
+	}
+	return previous.Close()
+}
+
+// Write implements io.Writer.
+func (s *SwitchWriter) Write(p []byte) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.w.Write(p)
+}
+
+// Sync flushes the current writer; see logr.SyncWriter.
+func (s *SwitchWriter) Sync() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return logr.SyncWriter(s.w)
+}
+
+// Switch waits for the writes in progress and sends the next ones to w.
+func (s *SwitchWriter) Switch(w io.Writer) {
+	s.mu.Lock()
+	s.w = w
+	s.mu.Unlock()
+}
@@ -0,0 +1,121 @@
+package config_test
+
+import (
+	"bytes"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
+)
+
+type backend struct {
+	sinks  map[string]config.Sink
+	closed bool
+}
+
+func (b *backend) Sinks() map[string]config.Sink {
+	return b.sinks
+}
+
+func (b *backend) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestReload(t *testing.T) {
+	var oldConsole, newConsole, newFile bytes.Buffer
+	current := &backend{sinks: map[string]config.Sink{
+		"console": config.NewSink(&oldConsole, logr.LevelInfo),
+		"file":    config.NewSink(io.Discard, logr.LevelInfo),
+		"removed": config.NewSink(io.Discard, logr.LevelInfo),
+	}}
+	// mudanças em tempo de execução
+	current.sinks["console"].Level.SetLevel(logr.LevelDebug)
+	current.sinks["file"].Level.SetLevel(logr.LevelError)
+
+	root := &atomic.Pointer[backend]{}
+	root.Store(current)
+	next := &backend{sinks: map[string]config.Sink{
+		"console": config.NewSink(&newConsole, logr.LevelInfo),
+		"file":    config.NewSink(&newFile, logr.LevelWarn),
+	}}
+	if err := config.Reload(root, config.Default(), func(*backend) *backend { return next }); err != nil {
+		t.Fatal(err)
+	}
+
+	if root.Load() != next || !current.closed {
+		t.Fatal("Reload did not swap in the new backend and close the previous one")
+	}
+	if got := next.sinks["console"].Level.Level(); got != logr.LevelDebug {
+		t.Errorf("console level = %s, want DEBUG carried over", got)
+	}
+	if got := next.sinks["file"].Level.Level(); got != logr.LevelWarn {
+		t.Errorf("file level = %s, want WARN from the new config", got)
+	}
+
+	// quem ainda escreve no backend anterior chega ao novo
+	_, _ = current.sinks["console"].Writer.Write([]byte("late"))
+	_, _ = current.sinks["removed"].Writer.Write([]byte("dropped"))
+	if oldConsole.Len() != 0 || newConsole.String() != "late" {
+		t.Errorf("late write went to %q and %q, want the new console", oldConsole.String(), newConsole.String())
+	}
+
+	invalid := config.Default()
+	invalid.Console.Level = "LOUD"
+	built := false
+	if err := config.Reload(root, invalid, func(*backend) *backend { built = true; return nil }); err == nil || built {
+		t.Errorf("Reload of an invalid config = %v (built %t), want an error before building", err, built)
+	}
+}
+
+// stuckWriter blocks every write until open is closed, reporting on entered
+// that one began.
+type stuckWriter struct {
+	open, entered chan struct{}
+}
+
+func (w stuckWriter) Write(p []byte) (int, error) {
+	select {
+	case w.entered <- struct{}{}:
+	default:
+	}
+	<-w.open
+	return len(p), nil
+}
+
+func TestReloadKeepsDropped(t *testing.T) {
+	out := stuckWriter{open: make(chan struct{}), entered: make(chan struct{}, 1)}
+	previous := logr.NewAsyncWriter(out, 1, logr.OverflowDropNewest)
+	defer previous.Close()
+
+	// o primeiro registro prende a goroutine, o segundo ocupa a fila
+	_, _ = previous.Write([]byte("0"))
+	<-out.entered
+	for _, record := range []string{"1", "2", "3"} {
+		_, _ = previous.Write([]byte(record))
+	}
+	close(out.open)
+
+	root := &atomic.Pointer[backend]{}
+	root.Store(&backend{sinks: map[string]config.Sink{"file": config.NewSink(previous, logr.LevelInfo)}})
+	successor := logr.NewAsyncWriter(io.Discard, 1, logr.OverflowDropNewest)
+	defer successor.Close()
+	next := &backend{sinks: map[string]config.Sink{"file": config.NewSink(successor, logr.LevelInfo)}}
+	if err := config.Reload(root, config.Default(), func(*backend) *backend { return next }); err != nil {
+		t.Fatal(err)
+	}
+
+	sink := next.sinks["file"]
+	if !sink.Async() || sink.Dropped() != 2 {
+		t.Errorf("Async() = %t, Dropped() = %d, want true and the 2 drops of the replaced sink", sink.Async(), sink.Dropped())
+	}
+	if sink := config.NewSink(io.Discard, logr.LevelInfo); sink.Async() || sink.Dropped() != 0 {
+		t.Errorf("synchronous sink: Async() = %t, Dropped() = %d, want false and 0", sink.Async(), sink.Dropped())
+	}
+	dropped := config.Dropped(map[string]config.Sink{"file": sink, "console": config.NewSink(io.Discard, logr.LevelInfo)})
+	if len(dropped) != 1 || dropped["file"] != 2 {
+		t.Errorf("Dropped = %v, want only the file sink with 2", dropped)
+	}
+}
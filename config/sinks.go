@@ -0,0 +1,134 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+)
+
+// SinkLevels returns the level of every sink, keyed by sink name. Changing a
+// returned level changes the sink.
+func SinkLevels(sinks map[string]Sink) map[string]*logr.AtomicLevel {
+	levels := make(map[string]*logr.AtomicLevel, len(sinks))
+	for name, sink := range sinks {
+		levels[name] = sink.Level
+	}
+	return levels
+}
+
+// Dropped returns Sink.Dropped of every sink written through a
+// logr.AsyncWriter, keyed by sink name. It backs logr.DropCounter.
+func Dropped(sinks map[string]Sink) map[string]uint64 {
+	dropped := make(map[string]uint64)
+	for name, sink := range sinks {
+		if sink.Async() {
+			dropped[name] = sink.Dropped()
+		}
+	}
+	return dropped
+}
+
+// LowestLevel returns the lowest level of sinks, or INFO when there are none.
+func LowestLevel(sinks map[string]Sink) logr.Level {
+	lowest, found := logr.LevelInfo, false
+	for _, sink := range sinks {
+		if current := sink.Level.Level(); !found || current < lowest {
+			lowest, found = current, true
+		}
+	}
+	return lowest
+}
+
+// SetLevel sets every sink to level.
+func SetLevel(sinks map[string]Sink, level logr.Level) {
+	for _, sink := range sinks {
+		sink.Level.SetLevel(level)
+	}
+}
+
+// Enabled reports whether some sink accepts records at level.
+func Enabled(sinks map[string]Sink, level logr.Level) bool {
+	for _, sink := range sinks {
+		if sink.Level.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateLevel reports a level the adapters would replace by INFO. name
+// prefixes the error; the empty level is valid.
+func ValidateLevel(name, level string) error {
+	if level == "" {
+		return nil
+	}
+	if _, err := logr.ParseLevel(level); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// ValidateFormat is ValidateLevel for formats, which the adapters replace by
+// TEXT.
+func ValidateFormat(name, format string) error {
+	if format == "" {
+		return nil
+	}
+	if _, err := logr.ParseFormat(format); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// ValidateSampling reports negative counts and sampling without an interval.
+func ValidateSampling(initial, thereafter int, interval time.Duration) error {
+	switch {
+	case initial < 0 || thereafter < 0:
+		return fmt.Errorf("sampling: initial and thereafter must not be negative, got %d and %d", initial, thereafter)
+	case initial > 0 && interval <= 0:
+		return errors.New("sampling: interval is required")
+	}
+	return nil
+}
+
+// Sync flushes the writers that buffer records; see logr.SyncWriter.
+func Sync(writers []io.Writer) error {
+	var errs []error
+	for _, w := range writers {
+		errs = append(errs, logr.SyncWriter(w))
+	}
+	return errors.Join(errs...)
+}
+
+// Close syncs writers, drains the logr.AsyncWriter among them and closes
+// those of type F, the files an adapter rotates. The other writers, such as
+// the console, belong to the caller and are left open.
+func Close[F io.Closer](writers []io.Writer) error {
+	errs := []error{Sync(writers)}
+	for _, w := range writers {
+		// a fila é esvaziada antes de o arquivo por trás dela ser fechado
+		if async, ok := w.(*logr.AsyncWriter); ok {
+			errs = append(errs, async.Close())
+			w = async.Unwrap()
+		}
+		if file, ok := w.(F); ok {
+			errs = append(errs, file.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Fatal ends a Fatal record once it is written: writers are flushed and
+// onFatal runs, or logr.Exit(1) when it is nil.
+func Fatal(writers []io.Writer, onFatal func()) {
+	// Fatal não retorna erro, então o do Sync se perde
+	_ = Sync(writers)
+	if onFatal != nil {
+		onFatal()
+		return
+	}
+	logr.Exit(1)
+}
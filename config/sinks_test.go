@@ -0,0 +1,96 @@
+package config_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/config"
+)
+
+func TestSinkLevels(t *testing.T) {
+	sinks := map[string]config.Sink{
+		"console": config.NewSink(io.Discard, logr.LevelWarn),
+		"file":    config.NewSink(io.Discard, logr.LevelDebug),
+	}
+
+	if got := config.LowestLevel(sinks); got != logr.LevelDebug {
+		t.Errorf("LowestLevel = %v, want DEBUG", got)
+	}
+	if !config.Enabled(sinks, logr.LevelDebug) || config.Enabled(sinks, logr.LevelTrace) {
+		t.Error("Enabled must follow the lowest sink")
+	}
+	if got := config.LowestLevel(nil); got != logr.LevelInfo {
+		t.Errorf("LowestLevel without sinks = %v, want INFO", got)
+	}
+
+	config.SinkLevels(sinks)["console"].SetLevel(logr.LevelError)
+	if got := sinks["console"].Level.Level(); got != logr.LevelError {
+		t.Errorf("console level = %v, want ERROR through SinkLevels", got)
+	}
+
+	config.SetLevel(sinks, logr.LevelWarn)
+	for name, sink := range sinks {
+		if got := sink.Level.Level(); got != logr.LevelWarn {
+			t.Errorf("%s level = %v, want WARN after SetLevel", name, got)
+		}
+	}
+}
+
+func TestValidateOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{"empty level", config.ValidateLevel("level", ""), false},
+		{"level", config.ValidateLevel("level", "warning"), false},
+		{"invalid level", config.ValidateLevel("level", "loud"), true},
+		{"empty format", config.ValidateFormat("formatter", ""), false},
+		{"invalid format", config.ValidateFormat("formatter", "xml"), true},
+		{"sampling off", config.ValidateSampling(0, 0, 0), false},
+		{"sampling", config.ValidateSampling(10, 100, time.Second), false},
+		{"negative sampling", config.ValidateSampling(-1, 0, time.Second), true},
+		{"sampling without interval", config.ValidateSampling(10, 0, 0), true},
+	}
+	for _, tt := range tests {
+		if (tt.err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, tt.err, tt.wantErr)
+		}
+	}
+}
+
+type closer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestClose(t *testing.T) {
+	file, console := &closer{}, &closer{}
+	async := logr.NewAsyncWriter(file, 8, logr.OverflowBlock)
+	if _, err := async.Write([]byte("queued\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.Close[*closer]([]io.Writer{async, &bytes.Buffer{}}); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !file.closed || file.String() != "queued\n" {
+		t.Errorf("file closed = %v with %q, want closed after the queued record", file.closed, file.String())
+	}
+
+	if err := config.Close[*os.File]([]io.Writer{console}); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if console.closed {
+		t.Error("Close closed a writer of another type")
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// DefaultWatchInterval is how often the adapters' NewFromFile check the
+// config file for changes.
+const DefaultWatchInterval = 5 * time.Second
+
+// Reloader is implemented by the loggers of every adapter. Reload rebuilds
+// the sinks from cfg and switches to them every logger derived from the same
+// constructor, including those already created with WithFields.
+type Reloader interface {
+	Reload(cfg Config) error
+}
+
+// Watch keeps r in sync with the JSON config file at name until ctx is done.
+// The file is loaded again when its modification time changes, checked every
+// interval, and when the process receives SIGHUP. A file that fails to load,
+// or a config r rejects, is given to onError and the previous config stays in
+// place.
+func Watch(ctx context.Context, name string, interval time.Duration, r Reloader, onError func(error)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	modTime := fileModTime(name)
+	reload := func() {
+		cfg, err := Load(name)
+		if err == nil {
+			err = r.Reload(cfg)
+		}
+		if err != nil {
+			onError(err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			modTime = fileModTime(name)
+			reload()
+		case <-ticker.C:
+			// o arquivo pode estar sendo reescrito; só recarrega quando muda
+			if current := fileModTime(name); !current.Equal(modTime) {
+				modTime = current
+				reload()
+			}
+		}
+	}
+}
+
+// fileModTime returns the zero time when name cannot be read, so the file
+// showing up again counts as a change.
+func fileModTime(name string) time.Time {
+	info, err := os.Stat(name)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,80 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr/config"
+)
+
+type reloader chan config.Config
+
+func (r reloader) Reload(cfg config.Config) error {
+	select {
+	case r <- cfg:
+	default:
+	}
+	return nil
+}
+
+func TestWatch(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "logging.json")
+	modTime := time.Now().Add(-time.Hour)
+	// write muda o arquivo com um mtime sempre novo, mesmo em sistemas de
+	// arquivos com pouca resolução de tempo
+	write := func(content string) {
+		t.Helper()
+		modTime = modTime.Add(time.Minute)
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(name, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`{"console": {"level": "INFO"}}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := make(reloader, 1)
+	errs := make(chan error, 1)
+	go config.Watch(ctx, name, 10*time.Millisecond, r, func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+
+	// Watch pode ainda não ter lido o mtime inicial, então a mudança é
+	// repetida até ser vista
+	change := func(content string) (config.Config, error) {
+		t.Helper()
+		deadline := time.After(2 * time.Second)
+		for {
+			write(content)
+			select {
+			case cfg := <-r:
+				return cfg, nil
+			case err := <-errs:
+				return config.Config{}, err
+			case <-deadline:
+				t.Fatalf("change to %s not picked up", content)
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+	}
+
+	if cfg, err := change(`{"console": {"level": "VERBOSE"}}`); err == nil {
+		t.Fatalf("invalid config applied: %+v", cfg)
+	}
+	cfg, err := change(`{"console": {"level": "DEBUG"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Console.Level != "DEBUG" {
+		t.Errorf("reloaded level = %q, want DEBUG", cfg.Console.Level)
+	}
+}
@@ -0,0 +1,28 @@
+package logr
+
+import (
+	"context"
+	"slices"
+)
+
+// ContextExtractor pulls fields, such as trace and span IDs, from the context
+// given to the *Context logging methods and FromContext.
+type ContextExtractor func(ctx context.Context) Fields
+
+// ContextFields returns stored, the fields a logger keeps in ctx with
+// ToContext, followed by those the extractors return, leaving out the keys
+// carried, the fields of the logger itself, already holds. A logger given back
+// the context it stored thus does not log its fields twice. The adapters call
+// it for the *Context methods and FromContext.
+func ContextFields(ctx context.Context, stored, carried Fields, extractors []ContextExtractor) Fields {
+	fields := slices.Clone(stored)
+	for _, extract := range extractors {
+		fields = append(fields, extract(ctx)...)
+	}
+	if len(carried) == 0 {
+		return fields
+	}
+	return slices.DeleteFunc(fields, func(f Field) bool {
+		return slices.ContainsFunc(carried, func(c Field) bool { return c.Key == f.Key })
+	})
+}
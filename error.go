@@ -0,0 +1,58 @@
+package logr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// maxErrorChain bounds ErrorChain for errors whose Unwrap never ends.
+const maxErrorChain = 32
+
+// ErrorLink is one error of the chain rendered for an ErrorType field.
+type ErrorLink struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// ErrorChain walks err and everything it wraps, through both errors.Unwrap
+// and errors.Join, returning one link per error in depth-first order. The
+// first link is err itself.
+func ErrorChain(err error) []ErrorLink {
+	// a cadeia de um erro mascarado já foi montada por SetMasker
+	if masked, ok := err.(maskedError); ok {
+		return slices.Clone(masked.chain)
+	}
+
+	var chain []ErrorLink
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil || len(chain) >= maxErrorChain {
+			return
+		}
+		chain = append(chain, ErrorLink{Message: err.Error(), Type: fmt.Sprintf("%T", err)})
+
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+			return
+		}
+		walk(errors.Unwrap(err))
+	}
+	walk(err)
+	return chain
+}
+
+// ErrorVerbose returns the "%+v" rendering of err when it carries more than
+// its message, such as the stack trace kept by github.com/pkg/errors, or an
+// empty string otherwise.
+func ErrorVerbose(err error) string {
+	if _, ok := err.(fmt.Formatter); !ok {
+		return ""
+	}
+	if verbose := fmt.Sprintf("%+v", err); verbose != err.Error() {
+		return verbose
+	}
+	return ""
+}
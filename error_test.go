@@ -0,0 +1,100 @@
+package logr_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+)
+
+func TestErr(t *testing.T) {
+	err := errors.New("boom")
+	for _, tc := range []struct {
+		field logr.Field
+		key   string
+	}{
+		{logr.Err(err), logr.ErrorKey},
+		{logr.NamedErr("cause", err), "cause"},
+	} {
+		if tc.field.Key != tc.key || tc.field.Type != logr.ErrorType || tc.field.Value != err {
+			t.Errorf("field = %+v, want key %q, ErrorType and the error", tc.field, tc.key)
+		}
+	}
+}
+
+func TestErrorChain(t *testing.T) {
+	base := errors.New("base")
+	other := errors.New("other")
+	err := fmt.Errorf("outer: %w", errors.Join(fmt.Errorf("inner: %w", base), other))
+
+	var got []string
+	for _, link := range logr.ErrorChain(err) {
+		got = append(got, link.Message)
+	}
+	want := []string{
+		"outer: inner: base\nother",
+		"inner: base\nother",
+		"inner: base",
+		"base",
+		"other",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+
+	chain := logr.ErrorChain(err)
+	if chain[0].Type != "*fmt.wrapError" || chain[3].Type != "*errors.errorString" {
+		t.Errorf("types = %q and %q, want *fmt.wrapError and *errors.errorString", chain[0].Type, chain[3].Type)
+	}
+
+	if chain := logr.ErrorChain(nil); chain != nil {
+		t.Errorf("ErrorChain(nil) = %v, want nil", chain)
+	}
+	if n := len(logr.ErrorChain(loopError{})); n != 32 {
+		t.Errorf("chain of an endless error has %d links, want 32", n)
+	}
+}
+
+func TestErrorVerbose(t *testing.T) {
+	if got := logr.ErrorVerbose(errors.New("plain")); got != "" {
+		t.Errorf("ErrorVerbose(plain) = %q, want empty", got)
+	}
+	if got := logr.ErrorVerbose(stackError{}); got != "stack\nmain.go:10" {
+		t.Errorf("ErrorVerbose(stackError) = %q, want the %%+v rendering", got)
+	}
+	// %+v igual à mensagem não acrescenta nada
+	if got := logr.ErrorVerbose(sameError{}); got != "" {
+		t.Errorf("ErrorVerbose(sameError) = %q, want empty", got)
+	}
+}
+
+// loopError wraps itself forever.
+type loopError struct{}
+
+func (loopError) Error() string { return "loop" }
+
+func (e loopError) Unwrap() error { return e }
+
+// stackError renders a stack trace under %+v, as github.com/pkg/errors.
+type stackError struct{}
+
+func (stackError) Error() string { return "stack" }
+
+func (e stackError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprint(s, "stack\nmain.go:10")
+		return
+	}
+	fmt.Fprint(s, e.Error())
+}
+
+// sameError is a fmt.Formatter whose %+v is just the message.
+type sameError struct{}
+
+func (sameError) Error() string { return "same" }
+
+func (e sameError) Format(s fmt.State, _ rune) {
+	fmt.Fprint(s, e.Error())
+}
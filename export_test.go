@@ -0,0 +1,18 @@
+package logr
+
+import "time"
+
+// SetRateLimitNow replaces the clock of WithRateLimit until the returned
+// func is called.
+func SetRateLimitNow(now func() time.Time) (restore func()) {
+	rateLimitNow = now
+	return func() { rateLimitNow = time.Now }
+}
+
+// SetRateLimitMaxWindows replaces the bound on the windows of a limiter until
+// the returned func is called.
+func SetRateLimitMaxWindows(n int) (restore func()) {
+	previous := rateLimitMaxWindows
+	rateLimitMaxWindows = n
+	return func() { rateLimitMaxWindows = previous }
+}
@@ -1,6 +1,9 @@
 package logr
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type (
 	FieldType int
@@ -23,6 +26,34 @@ const (
 	TimeType
 	DurationType
 	GroupType
+	ErrorType
+	Int64Type
+	Int32Type
+	UintType
+	Uint32Type
+	Float32Type
+	BytesType
+	HexType
+	StringsType
+	IntsType
+	StringerType
+	AnyType
+	ObjectType
+	LazyType
+	LazyGroupType
+	// SecretType fields never reach an encoder with their value: RedactFields
+	// replaces them first. An encoder that gets one anyway writes RedactedValue.
+	SecretType
+)
+
+// ErrorKey is the key used by Err.
+const ErrorKey = "error"
+
+// Suffixes of the keys added next to an ErrorType field: the chain of wrapped
+// errors and, when available, the verbose (stack trace) rendering.
+const (
+	ErrorChainSuffix   = "Chain"
+	ErrorVerboseSuffix = "Verbose"
 )
 
 func String(key, value string) Field {
@@ -37,6 +68,22 @@ func Int(key string, value int) Field {
 	return Field{Key: key, Value: value, Type: IntType}
 }
 
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value, Type: Int64Type}
+}
+
+func Int32(key string, value int32) Field {
+	return Field{Key: key, Value: value, Type: Int32Type}
+}
+
+func Uint(key string, value uint) Field {
+	return Field{Key: key, Value: value, Type: UintType}
+}
+
+func Uint32(key string, value uint32) Field {
+	return Field{Key: key, Value: value, Type: Uint32Type}
+}
+
 func Uint64(key string, value uint64) Field {
 	return Field{Key: key, Value: value, Type: Uint64Type}
 }
@@ -45,6 +92,40 @@ func Float64(key string, value float64) Field {
 	return Field{Key: key, Value: value, Type: Float64Type}
 }
 
+func Float32(key string, value float32) Field {
+	return Field{Key: key, Value: value, Type: Float32Type}
+}
+
+// Bytes logs value encoded as standard base64.
+func Bytes(key string, value []byte) Field {
+	return Field{Key: key, Value: value, Type: BytesType}
+}
+
+// Hex logs value encoded as lowercase hexadecimal.
+func Hex(key string, value []byte) Field {
+	return Field{Key: key, Value: value, Type: HexType}
+}
+
+func Strings(key string, value []string) Field {
+	return Field{Key: key, Value: value, Type: StringsType}
+}
+
+func Ints(key string, value []int) Field {
+	return Field{Key: key, Value: value, Type: IntsType}
+}
+
+// Stringer logs the result of value.String(), called only when the field is
+// rendered.
+func Stringer(key string, value fmt.Stringer) Field {
+	return Field{Key: key, Value: value, Type: StringerType}
+}
+
+// Any logs value with the backend's generic (usually reflection based)
+// encoding. Prefer a typed constructor when one exists.
+func Any(key string, value any) Field {
+	return Field{Key: key, Value: value, Type: AnyType}
+}
+
 func Time(key string, value time.Time) Field {
 	return Field{Key: key, Value: value, Type: TimeType}
 }
@@ -56,3 +137,15 @@ func Duration(key string, value time.Duration) Field {
 func Group(name string, fields ...Field) Field {
 	return Field{Key: name, Value: fields, Type: GroupType}
 }
+
+// Err logs err under ErrorKey; see NamedErr.
+func Err(err error) Field {
+	return NamedErr(ErrorKey, err)
+}
+
+// NamedErr logs err under key as its message, followed by key+"Chain" with the
+// ErrorChain of err and, when available, key+"Verbose" with ErrorVerbose. A nil
+// err is not logged.
+func NamedErr(key string, err error) Field {
+	return Field{Key: key, Value: err, Type: ErrorType}
+}
@@ -0,0 +1,65 @@
+package logr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Format is how a sink writes its records.
+type Format int
+
+const (
+	FormatText Format = iota
+	FormatJSON
+)
+
+// ErrInvalidFormat is returned by ParseFormat for names that are not a
+// format.
+var ErrInvalidFormat = errors.New("invalid format")
+
+var formatNames = [...]string{
+	FormatText: "TEXT",
+	FormatJSON: "JSON",
+}
+
+// ParseFormat returns the format named s, ignoring case.
+func ParseFormat(s string) (Format, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	for format, formatName := range formatNames {
+		if name == formatName {
+			return Format(format), nil
+		}
+	}
+	return 0, fmt.Errorf("%w %q, expected one of %s", ErrInvalidFormat, s, strings.Join(formatNames[:], ", "))
+}
+
+// String returns the name of the format, as in "JSON".
+func (f Format) String() string {
+	if f >= 0 && int(f) < len(formatNames) {
+		return formatNames[f]
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f Format) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Format) UnmarshalText(text []byte) error {
+	format, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = format
+	return nil
+}
+
+// FormatValue is what the format options of the adapters accept: a Format or
+// its name, as in WithConsoleFormatter(logr.FormatJSON) or
+// WithConsoleFormatter("JSON").
+type FormatValue interface {
+	~string | Format
+}
@@ -43,6 +43,14 @@ func Fatalf(format string, args ...interface{}) {
 	l.Fatalf(format, args...)
 }
 
+func Panic(message string) {
+	l.Panic(message)
+}
+
+func Panicf(format string, args ...interface{}) {
+	l.Panicf(format, args...)
+}
+
 func Debug(message string) {
 	l.Debug(message)
 }
@@ -51,6 +59,66 @@ func Debugf(format string, args ...interface{}) {
 	l.Debugf(format, args...)
 }
 
+func Trace(message string) {
+	l.Trace(message)
+}
+
+func Tracef(format string, args ...interface{}) {
+	l.Tracef(format, args...)
+}
+
+func Log(level Level, message string, fields ...Field) {
+	l.Log(level, message, fields...)
+}
+
+func Debugw(message string, fields ...Field) {
+	l.Debugw(message, fields...)
+}
+
+func Infow(message string, fields ...Field) {
+	l.Infow(message, fields...)
+}
+
+func Warnw(message string, fields ...Field) {
+	l.Warnw(message, fields...)
+}
+
+func Errorw(message string, fields ...Field) {
+	l.Errorw(message, fields...)
+}
+
+func InfoContext(ctx context.Context, message string) {
+	l.InfoContext(ctx, message)
+}
+
+func InfofContext(ctx context.Context, format string, args ...interface{}) {
+	l.InfofContext(ctx, format, args...)
+}
+
+func WarnContext(ctx context.Context, message string) {
+	l.WarnContext(ctx, message)
+}
+
+func WarnfContext(ctx context.Context, format string, args ...interface{}) {
+	l.WarnfContext(ctx, format, args...)
+}
+
+func ErrorContext(ctx context.Context, message string) {
+	l.ErrorContext(ctx, message)
+}
+
+func ErrorfContext(ctx context.Context, format string, args ...interface{}) {
+	l.ErrorfContext(ctx, format, args...)
+}
+
+func DebugContext(ctx context.Context, message string) {
+	l.DebugContext(ctx, message)
+}
+
+func DebugfContext(ctx context.Context, format string, args ...interface{}) {
+	l.DebugfContext(ctx, format, args...)
+}
+
 func WithFields(field ...Field) Logger {
 	return l.WithFields(field...)
 }
@@ -71,6 +139,22 @@ func GetFields() Fields {
 	return l.GetFields()
 }
 
+func GetLevel() Level {
+	return l.Level()
+}
+
+func SetLevel(level Level) {
+	l.SetLevel(level)
+}
+
+func Enabled(level Level) bool {
+	return l.Enabled(level)
+}
+
 func Output() io.Writer {
 	return l.Output()
 }
+
+func Sync() error {
+	return l.Sync()
+}
@@ -1,17 +1,3 @@
 module github.com/BrunoTulio/logr
 
 go 1.22.10
-
-require (
-	github.com/rs/zerolog v1.34.0
-	github.com/sirupsen/logrus v1.9.3
-	go.uber.org/zap v1.27.0
-	gopkg.in/natefinch/lumberjack.v2 v2.2.1
-)
-
-require (
-	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.19 // indirect
-	go.uber.org/multierr v1.11.0 // indirect
-	golang.org/x/sys v0.12.0 // indirect
-)
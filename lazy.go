@@ -0,0 +1,69 @@
+package logr
+
+// Lazy logs the value returned by fn, encoded as with Any. fn is only called
+// when a record is actually written, so it may be expensive.
+func Lazy(key string, fn func() any) Field {
+	return Field{Key: key, Value: fn, Type: LazyType}
+}
+
+// LazyGroup logs the fields returned by fn as a Group. fn is only called when
+// a record is actually written.
+func LazyGroup(key string, fn func() Fields) Field {
+	return Field{Key: key, Value: fn, Type: LazyGroupType}
+}
+
+// IsLazy reports whether f is a Lazy or LazyGroup field, or a Group holding
+// one of them.
+func (f Field) IsLazy() bool {
+	if f.Type == LazyType || f.Type == LazyGroupType {
+		return true
+	}
+	if f.Type != GroupType {
+		return false
+	}
+
+	groupFields, _ := f.Value.([]Field)
+	for _, gf := range groupFields {
+		if gf.IsLazy() {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitLazy separates the fields that IsLazy from the others, keeping their
+// relative order.
+func SplitLazy(fields Fields) (eager, lazy Fields) {
+	for _, f := range fields {
+		if f.IsLazy() {
+			lazy = append(lazy, f)
+		} else {
+			eager = append(eager, f)
+		}
+	}
+	return eager, lazy
+}
+
+// Resolve returns fields with every Lazy and LazyGroup replaced by the field
+// its function produces. Adapters call it only once a record is known to be
+// written.
+func Resolve(fields Fields) Fields {
+	resolved := make(Fields, 0, len(fields))
+	for _, f := range fields {
+		resolved = append(resolved, f.resolve())
+	}
+	return resolved
+}
+
+func (f Field) resolve() Field {
+	if fn, ok := f.Value.(func() any); ok && f.Type == LazyType {
+		return Any(f.Key, fn())
+	}
+	if fn, ok := f.Value.(func() Fields); ok && f.Type == LazyGroupType {
+		return Group(f.Key, Resolve(fn())...)
+	}
+	if groupFields, ok := f.Value.([]Field); ok && f.Type == GroupType && f.IsLazy() {
+		return Group(f.Key, Resolve(groupFields)...)
+	}
+	return f
+}
@@ -0,0 +1,74 @@
+package logr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+)
+
+func TestLazy(t *testing.T) {
+	calls := 0
+	lazy := logr.Lazy("lazy", func() any {
+		calls++
+		return calls
+	})
+	group := logr.LazyGroup("group", func() logr.Fields {
+		return logr.Fields{logr.Int("n", 1), lazy}
+	})
+	nested := logr.Group("outer", logr.String("s", "v"), lazy)
+	eager := logr.String("eager", "v")
+
+	for _, tc := range []struct {
+		field logr.Field
+		want  bool
+	}{
+		{lazy, true},
+		{group, true},
+		{nested, true},
+		{logr.Group("outer", eager), false},
+		{eager, false},
+	} {
+		if got := tc.field.IsLazy(); got != tc.want {
+			t.Errorf("%s.IsLazy() = %v, want %v", tc.field.Key, got, tc.want)
+		}
+	}
+	if calls != 0 {
+		t.Fatalf("fn called %d times before Resolve", calls)
+	}
+
+	resolved := logr.Resolve(logr.Fields{eager, lazy, group, nested})
+	want := logr.Fields{
+		eager,
+		logr.Any("lazy", 1),
+		logr.Group("group", logr.Int("n", 1), logr.Any("lazy", 2)),
+		logr.Group("outer", logr.String("s", "v"), logr.Any("lazy", 3)),
+	}
+	if !reflect.DeepEqual(resolved, want) {
+		t.Errorf("Resolve = %+v, want %+v", resolved, want)
+	}
+	for _, f := range resolved {
+		if f.IsLazy() {
+			t.Errorf("%s is still lazy after Resolve", f.Key)
+		}
+	}
+}
+
+func TestSplitLazy(t *testing.T) {
+	a, b := logr.String("a", "1"), logr.String("b", "2")
+	l1 := logr.Lazy("l1", func() any { return 1 })
+	l2 := logr.Group("l2", logr.Lazy("x", func() any { return 2 }))
+
+	eager, lazy := logr.SplitLazy(logr.Fields{a, l1, b, l2})
+	if len(eager) != 2 || eager[0].Key != "a" || eager[1].Key != "b" {
+		t.Errorf("eager = %+v, want [a b]", eager)
+	}
+	if len(lazy) != 2 || lazy[0].Key != "l1" || lazy[1].Key != "l2" {
+		t.Errorf("lazy = %+v, want [l1 l2]", lazy)
+	}
+
+	eager, lazy = logr.SplitLazy(logr.Fields{a, b})
+	if len(eager) != 2 || lazy != nil {
+		t.Errorf("SplitLazy without lazy fields = %+v, %+v, want both fields eager", eager, lazy)
+	}
+}
@@ -1,12 +1,115 @@
 package logr
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
 type (
 	Level int
+
+	// LevelValue is what the level options of the adapters accept: a Level or
+	// its name, as in WithConsoleLevel(logr.LevelDebug) or
+	// WithConsoleLevel("DEBUG").
+	LevelValue interface {
+		~string | Level
+	}
+
+	// AtomicLevel is a Level that can be read and changed concurrently,
+	// letting a running logger switch its verbosity without being rebuilt.
+	AtomicLevel struct {
+		level atomic.Int64
+	}
 )
 
 const (
-	LevelDebug Level = iota
+	LevelTrace Level = iota - 1
+	LevelDebug
 	LevelInfo
 	LevelWarn
 	LevelError
+	LevelPanic
+	LevelFatal
 )
+
+// ErrInvalidLevel is returned by ParseLevel for names that are not a level.
+var ErrInvalidLevel = errors.New("invalid level")
+
+// levelNames is indexed by the level minus LevelTrace.
+var levelNames = [...]string{
+	LevelTrace - LevelTrace: "TRACE",
+	LevelDebug - LevelTrace: "DEBUG",
+	LevelInfo - LevelTrace:  "INFO",
+	LevelWarn - LevelTrace:  "WARN",
+	LevelError - LevelTrace: "ERROR",
+	LevelPanic - LevelTrace: "PANIC",
+	LevelFatal - LevelTrace: "FATAL",
+}
+
+// levelAliases are the other names ParseLevel accepts.
+var levelAliases = map[string]Level{
+	"WARNING": LevelWarn,
+	"ERR":     LevelError,
+}
+
+// ParseLevel returns the level named s, ignoring case. Besides the names
+// returned by String, "warning" and "err" are accepted.
+func ParseLevel(s string) (Level, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	for i, levelName := range levelNames {
+		if name == levelName {
+			return Level(i) + LevelTrace, nil
+		}
+	}
+	if level, ok := levelAliases[name]; ok {
+		return level, nil
+	}
+	return 0, fmt.Errorf("%w %q, expected one of %s", ErrInvalidLevel, s, strings.Join(levelNames[:], ", "))
+}
+
+// String returns the name of the level, as in "WARN".
+func (l Level) String() string {
+	if l >= LevelTrace && l <= LevelFatal {
+		return levelNames[l-LevelTrace]
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the names
+// accepted by ParseLevel.
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+func NewAtomicLevel(level Level) *AtomicLevel {
+	a := &AtomicLevel{}
+	a.SetLevel(level)
+	return a
+}
+
+// Level returns the current level.
+func (a *AtomicLevel) Level() Level {
+	return Level(a.level.Load())
+}
+
+// SetLevel changes the level; the change is seen by every logger sharing a.
+func (a *AtomicLevel) SetLevel(level Level) {
+	a.level.Store(int64(level))
+}
+
+// Enabled reports whether a record at level passes the current level.
+func (a *AtomicLevel) Enabled(level Level) bool {
+	return level >= a.Level()
+}
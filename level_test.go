@@ -0,0 +1,35 @@
+package logr_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+)
+
+func TestAtomicLevel(t *testing.T) {
+	level := logr.NewAtomicLevel(logr.LevelWarn)
+	if got := level.Level(); got != logr.LevelWarn {
+		t.Fatalf("Level() = %s, want WARN", got)
+	}
+	for l, want := range map[logr.Level]bool{logr.LevelInfo: false, logr.LevelWarn: true, logr.LevelError: true} {
+		if got := level.Enabled(l); got != want {
+			t.Errorf("Enabled(%s) = %v, want %v", l, got, want)
+		}
+	}
+
+	// quem compartilha o AtomicLevel vê a mudança
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			level.SetLevel(logr.LevelDebug)
+			_ = level.Enabled(logr.LevelDebug)
+		}()
+	}
+	wg.Wait()
+	if !level.Enabled(logr.LevelDebug) {
+		t.Errorf("Enabled(DEBUG) = false after SetLevel(DEBUG)")
+	}
+}
@@ -18,9 +18,33 @@ type Logger interface {
 	Fatal(message string)
 	Fatalf(format string, args ...interface{})
 
+	Panic(message string)
+	Panicf(format string, args ...interface{})
+
 	Debug(message string)
 	Debugf(format string, args ...interface{})
 
+	Trace(message string)
+	Tracef(format string, args ...interface{})
+
+	Log(level Level, message string, fields ...Field)
+	Debugw(message string, fields ...Field)
+	Infow(message string, fields ...Field)
+	Warnw(message string, fields ...Field)
+	Errorw(message string, fields ...Field)
+
+	InfoContext(ctx context.Context, message string)
+	InfofContext(ctx context.Context, format string, args ...interface{})
+
+	WarnContext(ctx context.Context, message string)
+	WarnfContext(ctx context.Context, format string, args ...interface{})
+
+	ErrorContext(ctx context.Context, message string)
+	ErrorfContext(ctx context.Context, format string, args ...interface{})
+
+	DebugContext(ctx context.Context, message string)
+	DebugfContext(ctx context.Context, format string, args ...interface{})
+
 	WithFields(fields ...Field) Logger
 	WithField(field Field) Logger
 
@@ -28,5 +52,16 @@ type Logger interface {
 	FromContext(ctx context.Context) Logger
 	GetFields() Fields
 
+	Level() Level
+	SetLevel(level Level)
+	Enabled(level Level) bool
+
 	Output() io.Writer
+
+	// Sync flushes what the sinks still buffer.
+	Sync() error
+	// Close syncs and closes the files the sinks write to. They are shared by
+	// every logger derived from the same constructor, which must not be used
+	// afterwards.
+	Close() error
 }
@@ -0,0 +1,968 @@
+// Package conformance checks that a logr.Logger implementation behaves like
+// the built-in adapters, so applications can switch between them without
+// changing what ends up in their logs.
+//
+// An adapter proves conformance from its own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, func(w io.Writer, level logr.Level) logr.Logger {
+//			l := zap.New(zap.WithConsole(true), zap.WithConsoleWriter(w), zap.WithConsoleFormatter("JSON"))
+//			l.SetLevel(level)
+//			return l
+//		})
+//	}
+package conformance
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+)
+
+// Factory creates the logger under test. The logger must write one JSON
+// object per record to w and log records at level and above.
+type Factory func(w io.Writer, level logr.Level) logr.Logger
+
+// FatalFactory creates the logger under test for RunFatal. Like Factory, the
+// logger writes JSON to w, at INFO and above; onFatal replaces what Fatal
+// does after writing, as the OnFatal option of the adapters.
+type FatalFactory func(w io.Writer, onFatal func()) logr.Logger
+
+// SamplingFactory creates the logger under test for RunSampling. Like
+// Factory, the logger writes JSON to w, at INFO and above, and samples as the
+// WithSampling option of the adapters.
+type SamplingFactory func(w io.Writer, initial, thereafter int, interval time.Duration) logr.Logger
+
+// ExtractorFactory creates the logger under test for RunContextExtractors.
+// Like Factory, the logger writes JSON to w at level and above, and runs
+// extractor as the WithContextExtractor option of the adapters.
+type ExtractorFactory func(w io.Writer, level logr.Level, extractor logr.ContextExtractor) logr.Logger
+
+// CallerFactory creates the logger under test for RunCaller. Like Factory,
+// the logger writes JSON to w, at INFO and above, and reports the caller as
+// the WithAddSource option of the adapters.
+type CallerFactory func(w io.Writer) logr.Logger
+
+// Record is a decoded log line.
+type Record map[string]any
+
+const levelKey = "level"
+
+// messageKeys are the keys accepted for the message of a record; backends
+// disagree on them and both spellings are common.
+var messageKeys = []string{"msg", "message"}
+
+// Run checks the logger built by factory against the behaviour shared by every
+// logr adapter.
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+
+	t.Run("Levels", func(t *testing.T) { testLevels(t, factory) })
+	t.Run("Trace", func(t *testing.T) { testTrace(t, factory) })
+	t.Run("Panic", func(t *testing.T) { testPanic(t, factory) })
+	t.Run("SetLevel", func(t *testing.T) { testSetLevel(t, factory) })
+	t.Run("Messages", func(t *testing.T) { testMessages(t, factory) })
+	t.Run("Fields", func(t *testing.T) { testFields(t, factory) })
+	t.Run("Groups", func(t *testing.T) { testGroups(t, factory) })
+	t.Run("Redaction", func(t *testing.T) { testRedaction(t, factory) })
+	t.Run("Masking", func(t *testing.T) { testMasking(t, factory) })
+	t.Run("MaskedPanic", func(t *testing.T) { testMaskedPanic(t, factory) })
+	t.Run("Lazy", func(t *testing.T) { testLazy(t, factory) })
+	t.Run("Context", func(t *testing.T) { testContext(t, factory) })
+	t.Run("ContextOwnFields", func(t *testing.T) { testContextOwnFields(t, factory) })
+	t.Run("WithFields", func(t *testing.T) { testWithFields(t, factory) })
+	t.Run("JSON", func(t *testing.T) { testJSON(t, factory) })
+	t.Run("Close", func(t *testing.T) { testClose(t, factory) })
+}
+
+// RunFatal checks that Fatal, Fatalf and Log at LevelFatal write their record,
+// flush the writer and only then call onFatal, once per call.
+func RunFatal(t *testing.T, factory FatalFactory) {
+	t.Helper()
+
+	for name, fatal := range map[string]func(logr.Logger){
+		"Fatal":  func(l logr.Logger) { l.Fatal("fatal") },
+		"Fatalf": func(l logr.Logger) { l.Fatalf("fa%s", "tal") },
+		"Log":    func(l logr.Logger) { l.Log(logr.LevelFatal, "fatal") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := &syncWriter{}
+			calls := 0
+			l := factory(w, func() {
+				calls++
+				if !w.synced {
+					t.Error("onFatal called before the writer was synced")
+				}
+			})
+
+			fatal(l)
+
+			if calls != 1 {
+				t.Fatalf("onFatal called %d times, want 1", calls)
+			}
+			r := single(t, Decode(t, &w.buf))
+			if got := r.Message(); got != "fatal" {
+				t.Errorf("message = %q, want %q", got, "fatal")
+			}
+			if got, ok := r.Level(); !ok || got != logr.LevelFatal {
+				t.Errorf("level = %v (found %t), want %v", got, ok, logr.LevelFatal)
+			}
+		})
+	}
+}
+
+func testClose(t *testing.T, factory Factory) {
+	w := &closeWriter{}
+	l := factory(w, logr.LevelInfo)
+
+	l.Info("before close")
+	if err := l.Sync(); err != nil {
+		t.Errorf("Sync() = %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+	if w.closed {
+		t.Error("Close closed the writer given to the logger; it belongs to the caller")
+	}
+	single(t, Decode(t, &w.buf))
+}
+
+// closeWriter records whether it was closed.
+type closeWriter struct {
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (w *closeWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *closeWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+// RunSampling checks that a repeated record is kept only as often as the
+// sampling allows and that Close reports the suppressed ones in a record with
+// logr.SamplingMessage.
+func RunSampling(t *testing.T, factory SamplingFactory) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	// o intervalo longo deixa o relatório só para o Close
+	l := factory(&buf, 2, 3, time.Hour)
+
+	for range 10 {
+		l.Info("repeated")
+	}
+	l.Info("other")
+	l.Info("other")
+	// PANIC e FATAL nunca são amostrados
+	for range 5 {
+		panicked(func() { l.Panic("panic") })
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var kept, other, panics int
+	var report Record
+	for _, r := range Decode(t, &buf) {
+		switch r.Message() {
+		case "repeated":
+			kept++
+		case "other":
+			other++
+		case "panic":
+			panics++
+		case logr.SamplingMessage:
+			report = r
+		}
+	}
+	// os 2 iniciais e depois 1 a cada 3: o 5º e o 8º
+	if kept != 4 {
+		t.Errorf("kept %d of 10 repeated records, want 4", kept)
+	}
+	if other != 2 {
+		t.Errorf("kept %d of 2 other records, want 2: sampling is by level and message", other)
+	}
+	if panics != 5 {
+		t.Errorf("kept %d of 5 Panic records, want all of them", panics)
+	}
+	if report == nil {
+		t.Fatalf("no %q record after Close", logr.SamplingMessage)
+	}
+	if got, _ := report["suppressed"].(json.Number); got != "6" {
+		t.Errorf("suppressed = %v, want 6", report["suppressed"])
+	}
+}
+
+// RunContextExtractors checks that the *Context methods run the context
+// extractors only for the records they write, once per record, and log the
+// extracted fields with the record.
+func RunContextExtractors(t *testing.T, factory ExtractorFactory) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	calls := 0
+	l := factory(&buf, logr.LevelWarn, func(ctx context.Context) logr.Fields {
+		calls++
+		return logr.Fields{logr.String("trace_id", "t1")}
+	})
+	ctx := context.Background()
+
+	l.DebugContext(ctx, "disabled")
+	l.InfoContext(ctx, "disabled")
+	l.InfofContext(ctx, "dis%s", "abled")
+	if calls != 0 {
+		t.Fatalf("extractor called %d times for disabled records", calls)
+	}
+
+	l.WarnContext(ctx, "warn")
+	l.ErrorfContext(ctx, "er%s", "ror")
+	if calls != 2 {
+		t.Errorf("extractor called %d times for 2 records, want 2", calls)
+	}
+	records := Decode(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("got %d records %v, want 2", len(records), records)
+	}
+	for _, r := range records {
+		if r["trace_id"] != "t1" {
+			t.Errorf("%q: trace_id = %v, want t1", r.Message(), r["trace_id"])
+		}
+	}
+
+	// o logger derivado não muda o logger original
+	if fields := l.GetFields(); len(fields) != 0 {
+		t.Errorf("GetFields() = %v after the *Context methods, want none", fields)
+	}
+}
+
+// RunCaller checks that the caller of every record is the code calling the
+// logr method, not the adapter: a value ending in "conformance.go:<line>", or
+// "conformance.go" next to a "line", as slog writes it.
+func RunCaller(t *testing.T, factory CallerFactory) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	l := factory(&buf)
+	ctx := context.Background()
+
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("caller")
+	l.Infof("call%s", "er")
+	l.Infow("caller", logr.Int("n", 1))
+	l.InfoContext(ctx, "caller")
+	l.WarnfContext(ctx, "call%s", "er")
+	l.Log(logr.LevelError, "caller")
+	l.WithFields(logr.String("k", "v")).Info("caller")
+
+	records := Decode(t, &buf)
+	if len(records) != 7 {
+		t.Fatalf("got %d records %v, want 7", len(records), records)
+	}
+	for i, r := range records {
+		want := line + 1 + i
+		if !hasCaller(r, want) {
+			t.Errorf("record %d: no caller at conformance.go:%d in %s", i, want, mustJSON(r))
+		}
+	}
+}
+
+// hasCaller reports whether v holds the caller conformance.go:line.
+func hasCaller(v any, line int) bool {
+	switch v := v.(type) {
+	case string:
+		return strings.HasSuffix(v, fmt.Sprintf("conformance.go:%d", line))
+	case map[string]any:
+		if file, _ := v["file"].(string); strings.HasSuffix(file, "conformance.go") && jsonEqual(v["line"], strconv.Itoa(line)) {
+			return true
+		}
+		for _, nested := range v {
+			if hasCaller(nested, line) {
+				return true
+			}
+		}
+	case Record:
+		return hasCaller(map[string]any(v), line)
+	}
+	return false
+}
+
+// syncWriter records whether Sync was called after the last write.
+type syncWriter struct {
+	buf    bytes.Buffer
+	synced bool
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.synced = false
+	return w.buf.Write(p)
+}
+
+func (w *syncWriter) Sync() error {
+	w.synced = true
+	return nil
+}
+
+func testLevels(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelWarn)
+
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+	l.Infof("info %d", 1)
+	l.Warnf("warn %d", 1)
+	l.Log(logr.LevelInfo, "log info")
+	l.Log(logr.LevelError, "log error")
+	l.Debugw("debugw")
+	l.Errorw("errorw")
+
+	records := Decode(t, &buf)
+	want := []struct {
+		level   logr.Level
+		message string
+	}{
+		{logr.LevelWarn, "warn"},
+		{logr.LevelError, "error"},
+		{logr.LevelWarn, "warn 1"},
+		{logr.LevelError, "log error"},
+		{logr.LevelError, "errorw"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records %v, want %d", len(records), records, len(want))
+	}
+	for i, w := range want {
+		if got := records[i].Message(); got != w.message {
+			t.Errorf("record %d: message = %q, want %q", i, got, w.message)
+		}
+		if got, ok := records[i].Level(); !ok || got != w.level {
+			t.Errorf("record %d: level = %v (found %t), want %v", i, got, ok, w.level)
+		}
+	}
+
+	if got := l.Level(); got != logr.LevelWarn {
+		t.Errorf("Level() = %v, want %v", got, logr.LevelWarn)
+	}
+	for level, want := range map[logr.Level]bool{
+		logr.LevelDebug: false,
+		logr.LevelInfo:  false,
+		logr.LevelWarn:  true,
+		logr.LevelError: true,
+	} {
+		if got := l.Enabled(level); got != want {
+			t.Errorf("Enabled(%v) = %t, want %t", level, got, want)
+		}
+	}
+}
+
+func testTrace(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelDebug)
+
+	l.Trace("hidden")
+	l.SetLevel(logr.LevelTrace)
+	l.Trace("trace")
+	l.Tracef("trace %d", 1)
+
+	records := Decode(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("got %d records %v, want 2", len(records), records)
+	}
+	for i, message := range []string{"trace", "trace 1"} {
+		if got := records[i].Message(); got != message {
+			t.Errorf("record %d: message = %q, want %q", i, got, message)
+		}
+		if got, ok := records[i].Level(); !ok || got != logr.LevelTrace {
+			t.Errorf("record %d: level = %v (found %t), want %v", i, got, ok, logr.LevelTrace)
+		}
+	}
+}
+
+func testPanic(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelError)
+
+	// o valor do pânico é a mensagem, qualquer que seja o backend
+	if r := panicked(func() { l.Panic("boom") }); r != "boom" {
+		t.Errorf("Panic panicked with %#v, want %q", r, "boom")
+	}
+	if r := panicked(func() { l.Panicf("boom %d", 1) }); r != "boom 1" {
+		t.Errorf("Panicf panicked with %#v, want %q", r, "boom 1")
+	}
+	if r := panicked(func() { l.Log(logr.LevelPanic, "boom 2") }); r != "boom 2" {
+		t.Errorf("Log(LevelPanic) panicked with %#v, want %q", r, "boom 2")
+	}
+
+	records := Decode(t, &buf)
+	if len(records) != 3 {
+		t.Fatalf("got %d records %v, want 3: Panic must write before panicking", len(records), records)
+	}
+	for i, message := range []string{"boom", "boom 1", "boom 2"} {
+		if got := records[i].Message(); got != message {
+			t.Errorf("record %d: message = %q, want %q", i, got, message)
+		}
+		if got, ok := records[i].Level(); !ok || got != logr.LevelPanic {
+			t.Errorf("record %d: level = %v (found %t), want %v", i, got, ok, logr.LevelPanic)
+		}
+	}
+}
+
+func testSetLevel(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelError)
+	child := l.WithFields(logr.String("child", "yes"))
+
+	l.SetLevel(logr.LevelDebug)
+	child.Debug("debug")
+
+	records := Decode(t, &buf)
+	if len(records) != 1 {
+		t.Fatalf("got %d records %v, want 1: SetLevel must reach derived loggers", len(records), records)
+	}
+	if got, _ := records[0].Level(); got != logr.LevelDebug {
+		t.Errorf("level = %v, want %v", got, logr.LevelDebug)
+	}
+	if got := child.Level(); got != logr.LevelDebug {
+		t.Errorf("child Level() = %v, want %v", got, logr.LevelDebug)
+	}
+}
+
+func testMessages(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelDebug)
+	ctx := context.Background()
+
+	l.Infof("user %s has %d items", "ana", 3)
+	l.Infof("100%%")
+	l.Info("%s stays literal")
+	l.InfofContext(ctx, "ctx %v", true)
+	l.Info("quotes \" and\nnew line")
+
+	want := []string{
+		"user ana has 3 items",
+		"100%",
+		"%s stays literal",
+		"ctx true",
+		"quotes \" and\nnew line",
+	}
+	records := Decode(t, &buf)
+	if len(records) != len(want) {
+		t.Fatalf("got %d records %v, want %d", len(records), records, len(want))
+	}
+	for i := range want {
+		if got := records[i].Message(); got != want[i] {
+			t.Errorf("record %d: message = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+type object struct{}
+
+func (object) MarshalLogObject(e logr.ObjectEncoder) error {
+	e.AddString("name", "ana")
+	e.AddInt("age", 30)
+	return nil
+}
+
+// fieldCase is a field with the JSON it must render to. Values whose encoding
+// legitimately varies between backends are checked by check instead.
+type fieldCase struct {
+	field logr.Field
+	want  string
+	check func(v any) bool
+}
+
+// fieldCases lists one case per FieldType.
+func fieldCases() []fieldCase {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	return []fieldCase{
+		{field: logr.String("string", "value"), want: `"value"`},
+		{field: logr.Bool("bool", true), want: `true`},
+		{field: logr.Int("int", -1), want: `-1`},
+		{field: logr.Int64("int64", 1<<40), want: `1099511627776`},
+		{field: logr.Int32("int32", 32), want: `32`},
+		{field: logr.Uint("uint", 7), want: `7`},
+		{field: logr.Uint32("uint32", 32), want: `32`},
+		{field: logr.Uint64("uint64", 1<<63), want: `9223372036854775808`},
+		{field: logr.Float32("float32", 0.1), want: `0.1`},
+		{field: logr.Float64("float64", 1.5), want: `1.5`},
+		{field: logr.Bytes("bytes", []byte("hi")), want: `"aGk="`},
+		{field: logr.Hex("hex", []byte{0x01, 0xff}), want: `"01ff"`},
+		{field: logr.Strings("strings", []string{"a", "b"}), want: `["a","b"]`},
+		{field: logr.Ints("ints", []int{1, 2}), want: `[1,2]`},
+		{field: logr.Stringer("stringer", net.IPv4(10, 0, 0, 1)), want: `"10.0.0.1"`},
+		{field: logr.Any("any", map[string]int{"k": 1}), want: `{"k":1}`},
+		{field: logr.Object("object", object{}), want: `{"age":30,"name":"ana"}`},
+		{field: logr.Group("group", logr.String("a", "b")), want: `{"a":"b"}`},
+		{field: logr.Lazy("lazy", func() any { return 7 }), want: `7`},
+		{field: logr.LazyGroup("lazyGroup", func() logr.Fields { return logr.Fields{logr.Int("x", 1)} }), want: `{"x":1}`},
+		{field: logr.Err(errors.New("boom")), want: `"boom"`},
+		{field: logr.Time("time_field", ts), check: func(v any) bool { return isTime(v, ts) }},
+		{field: logr.Duration("duration", 1500*time.Millisecond), check: func(v any) bool { return isDuration(v, 1500*time.Millisecond) }},
+	}
+}
+
+func testFields(t *testing.T, factory Factory) {
+	cases := fieldCases()
+	fields := make(logr.Fields, 0, len(cases))
+	seen := make(map[logr.FieldType]bool)
+	for _, c := range cases {
+		fields = append(fields, c.field)
+		seen[c.field.Type] = true
+	}
+	for typ := logr.StringType; typ <= logr.LazyGroupType; typ++ {
+		if !seen[typ] {
+			t.Fatalf("FieldType %d has no conformance case", typ)
+		}
+	}
+
+	paths := map[string]func(l logr.Logger){
+		"WithFields": func(l logr.Logger) { l.WithFields(fields...).Info("fields") },
+		"Infow":      func(l logr.Logger) { l.Infow("fields", fields...) },
+		"Log":        func(l logr.Logger) { l.Log(logr.LevelInfo, "fields", fields...) },
+	}
+	for name, log := range paths {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log(factory(&buf, logr.LevelDebug))
+
+			r := single(t, Decode(t, &buf))
+			for _, c := range cases {
+				v, ok := r[c.field.Key]
+				if !ok {
+					t.Errorf("FieldType %d: key %q missing in %v", c.field.Type, c.field.Key, r)
+					continue
+				}
+				if c.check != nil {
+					if !c.check(v) {
+						t.Errorf("FieldType %d: %q = %v, not accepted", c.field.Type, c.field.Key, v)
+					}
+					continue
+				}
+				if !jsonEqual(v, c.want) {
+					t.Errorf("FieldType %d: %q = %s, want %s", c.field.Type, c.field.Key, mustJSON(v), c.want)
+				}
+			}
+
+			chain := logr.ErrorKey + logr.ErrorChainSuffix
+			if want := `[{"message":"boom","type":"*errors.errorString"}]`; !jsonEqual(r[chain], want) {
+				t.Errorf("%q = %s, want %s", chain, mustJSON(r[chain]), want)
+			}
+		})
+	}
+}
+
+func testGroups(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelDebug)
+
+	l.WithFields(
+		logr.Group("http",
+			logr.String("method", "GET"),
+			logr.Group("response", logr.Int("status", 200)),
+		),
+	).Infow("request", logr.Group("user", logr.String("id", "u1")))
+
+	r := single(t, Decode(t, &buf))
+	if want := `{"method":"GET","response":{"status":200}}`; !jsonEqual(r["http"], want) {
+		t.Errorf("http = %s, want %s", mustJSON(r["http"]), want)
+	}
+	if want := `{"id":"u1"}`; !jsonEqual(r["user"], want) {
+		t.Errorf("user = %s, want %s", mustJSON(r["user"]), want)
+	}
+}
+
+func testRedaction(t *testing.T, factory Factory) {
+	// restaura a política de quem roda a suíte
+	mode, patterns := logr.GetRedaction()
+	t.Cleanup(func() { logr.SetRedaction(mode, patterns...) })
+	logr.SetRedaction(logr.RedactMask, "password", "*token*")
+
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelInfo)
+
+	l.WithFields(logr.String("accessToken", "t1")).Infow("login",
+		logr.Secret("card", "4111111111111111"),
+		logr.Group("user",
+			logr.String("name", "ana"),
+			logr.String("Password", "p1"),
+		),
+		logr.Object("request", logr.ObjectMarshalerFunc(func(enc logr.ObjectEncoder) error {
+			enc.AddString("refresh_token", "t2")
+			enc.AddInt("attempt", 1)
+			return nil
+		})),
+	)
+
+	r := single(t, Decode(t, &buf))
+	redacted := fmt.Sprintf("%q", logr.RedactedValue)
+	for key, want := range map[string]string{
+		"accessToken": redacted,
+		"card":        redacted,
+		"user":        `{"name":"ana","Password":` + redacted + `}`,
+		"request":     `{"refresh_token":` + redacted + `,"attempt":1}`,
+	} {
+		if !jsonEqual(r[key], want) {
+			t.Errorf("%s = %s, want %s", key, mustJSON(r[key]), want)
+		}
+	}
+}
+
+func testMasking(t *testing.T, factory Factory) {
+	masker := logr.GetMasker()
+	t.Cleanup(func() { logr.SetMasker(masker) })
+	logr.SetMasker(logr.NewMasker(logr.EmailRule()))
+
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelInfo)
+
+	l.WithFields(logr.String("from", "bob@example.com")).Infof("sent to %s", "ana@example.com")
+	l.Infow("sent", logr.Group("to", logr.String("email", "ana@example.com")))
+	l.Infow("failed",
+		logr.Err(fmt.Errorf("notify: %w", errors.New("ana@example.com refused"))),
+		logr.Any("user", map[string]string{"email": "ana@example.com"}),
+	)
+
+	records := Decode(t, &buf)
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+	if got, want := records[0].Message(), "sent to a***@example.com"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+	if !jsonEqual(records[0]["from"], `"b***@example.com"`) {
+		t.Errorf("from = %s, want the email masked", mustJSON(records[0]["from"]))
+	}
+	if !jsonEqual(records[1]["to"], `{"email":"a***@example.com"}`) {
+		t.Errorf("to = %s, want the email masked", mustJSON(records[1]["to"]))
+	}
+	failed := records[2]
+	if !jsonEqual(failed[logr.ErrorKey], `"notify: a***@example.com refused"`) {
+		t.Errorf("%s = %s, want the email masked", logr.ErrorKey, mustJSON(failed[logr.ErrorKey]))
+	}
+	chainKey := logr.ErrorKey + logr.ErrorChainSuffix
+	if chain := mustJSON(failed[chainKey]); strings.Contains(chain, "ana@") || !strings.Contains(chain, "a***@example.com refused") {
+		t.Errorf("%s = %s, want the email masked in every link", chainKey, chain)
+	}
+	if !jsonEqual(failed["user"], `{"email":"a***@example.com"}`) {
+		t.Errorf("user = %s, want the email masked", mustJSON(failed["user"]))
+	}
+}
+
+func testMaskedPanic(t *testing.T, factory Factory) {
+	masker := logr.GetMasker()
+	t.Cleanup(func() { logr.SetMasker(masker) })
+	m := logr.NewMasker(logr.EmailRule())
+	logr.SetMasker(m)
+
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelError)
+
+	// quem recupera o pânico costuma logá-lo ou reportá-lo
+	want := "user j***@example.com failed"
+	for name, log := range map[string]func(){
+		"Panic":           func() { l.Panic("user john@example.com failed") },
+		"Panicf":          func() { l.Panicf("user %s failed", "john@example.com") },
+		"Log(LevelPanic)": func() { l.Log(logr.LevelPanic, "user john@example.com failed") },
+	} {
+		if r := panicked(log); r != want {
+			t.Errorf("%s panicked with %#v, want %q", name, r, want)
+		}
+	}
+	if got := m.Counts()["email"]; got != 3 {
+		t.Errorf("email count = %d after 3 panics, want 3: the message is masked once", got)
+	}
+}
+
+func testLazy(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelInfo)
+
+	calls := 0
+	lazy := logr.Lazy("lazy", func() any {
+		calls++
+		return calls
+	})
+
+	counter := &countingStringer{}
+
+	l.WithFields(lazy).Debug("disabled")
+	l.Debugw("disabled", lazy)
+	l.Debugf("%v", counter)
+	l.DebugfContext(context.Background(), "%v", counter)
+	if calls != 0 {
+		t.Fatalf("lazy field resolved %d times for disabled records", calls)
+	}
+	if counter.calls != 0 {
+		t.Fatalf("message formatted %d times for disabled records", counter.calls)
+	}
+
+	l.WithFields(lazy).Info("enabled")
+	r := single(t, Decode(t, &buf))
+	if !jsonEqual(r["lazy"], `1`) {
+		t.Errorf("lazy = %s, want 1", mustJSON(r["lazy"]))
+	}
+}
+
+// countingStringer counts how many times a message using it is formatted.
+type countingStringer struct {
+	calls int
+}
+
+func (c *countingStringer) String() string {
+	c.calls++
+	return "formatted"
+}
+
+func testContext(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelDebug)
+
+	ctx := l.WithFields(logr.String("request_id", "r1")).ToContext(context.Background())
+
+	l.FromContext(ctx).Info("from context")
+	l.InfoContext(ctx, "info context")
+	l.WarnfContext(ctx, "warn %s", "context")
+	l.FromContext(context.Background()).Info("empty context")
+
+	records := Decode(t, &buf)
+	if len(records) != 4 {
+		t.Fatalf("got %d records %v, want 4", len(records), records)
+	}
+	for _, r := range records[:3] {
+		if r["request_id"] != "r1" {
+			t.Errorf("%q: request_id = %v, want r1", r.Message(), r["request_id"])
+		}
+	}
+	if v, ok := records[3]["request_id"]; ok {
+		t.Errorf("%q: request_id = %v, want none", records[3].Message(), v)
+	}
+
+	fields := l.FromContext(ctx).GetFields()
+	if len(fields) != 1 || fields[0].Key != "request_id" {
+		t.Errorf("FromContext(ctx).GetFields() = %v, want [request_id]", fields)
+	}
+}
+
+// testContextOwnFields checks that a logger given back the context it stored
+// with ToContext does not log its own fields twice.
+func testContextOwnFields(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelDebug).WithFields(logr.String("req", "1"))
+	ctx := l.ToContext(context.Background())
+
+	l.InfoContext(ctx, "info context")
+	l.FromContext(ctx).Info("from context")
+	l.FromContext(ctx).InfoContext(ctx, "both")
+
+	// o Decode guardaria só a última chave repetida; conta no texto
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d records %q, want 3", len(lines), lines)
+	}
+	for _, line := range lines {
+		if n := strings.Count(line, `"req":`); n != 1 {
+			t.Errorf("req appears %d times in %s, want once", n, line)
+		}
+	}
+}
+
+func testWithFields(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelDebug)
+
+	base := l.WithFields(logr.String("a", "1"))
+	left := base.WithFields(logr.String("b", "2"))
+	right := base.WithField(logr.String("c", "3"))
+
+	l.Info("root")
+	base.Info("base")
+	left.Info("left")
+	right.Info("right")
+
+	want := map[string][]string{
+		"root":  nil,
+		"base":  {"a"},
+		"left":  {"a", "b"},
+		"right": {"a", "c"},
+	}
+	records := Decode(t, &buf)
+	if len(records) != len(want) {
+		t.Fatalf("got %d records %v, want %d", len(records), records, len(want))
+	}
+	for _, r := range records {
+		keys := want[r.Message()]
+		for _, key := range []string{"a", "b", "c"} {
+			_, got := r[key]
+			if wanted := slices.Contains(keys, key); got != wanted {
+				t.Errorf("%q: has %q = %t, want %t", r.Message(), key, got, wanted)
+			}
+		}
+	}
+
+	if got := len(l.GetFields()); got != 0 {
+		t.Errorf("root GetFields() has %d fields, want 0", got)
+	}
+	if got := len(base.GetFields()); got != 1 {
+		t.Errorf("base GetFields() has %d fields, want 1", got)
+	}
+}
+
+func testJSON(t *testing.T, factory Factory) {
+	var buf bytes.Buffer
+	l := factory(&buf, logr.LevelDebug)
+
+	l.WithFields(logr.String("key", "value")).Info("shape")
+
+	r := single(t, Decode(t, &buf))
+	if _, ok := lookup(r, messageKeys); !ok {
+		t.Errorf("record %v has no message key (one of %v)", r, messageKeys)
+	}
+	if _, ok := r.Level(); !ok {
+		t.Errorf("record %v has no valid %q key", r, levelKey)
+	}
+	if r["key"] != "value" {
+		t.Errorf("key = %v, want value at the top level", r["key"])
+	}
+}
+
+// Decode reads the records written to r, failing t unless every line is a
+// single JSON object.
+func Decode(t testing.TB, r io.Reader) []Record {
+	t.Helper()
+
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		var record Record
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatalf("line %q is not a JSON object: %v", line, err)
+		}
+		if decoder.More() {
+			t.Fatalf("line %q holds more than one JSON value", line)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+// Message returns the message of r, whichever of the usual keys holds it.
+func (r Record) Message() string {
+	v, _ := lookup(r, messageKeys)
+	s, _ := v.(string)
+	return s
+}
+
+// Level returns the level of r, accepting the names used by the common
+// backends in any case ("warning" included).
+func (r Record) Level() (logr.Level, bool) {
+	s, _ := r[levelKey].(string)
+	level, err := logr.ParseLevel(s)
+	return level, err == nil
+}
+
+func lookup(r Record, keys []string) (any, bool) {
+	for _, key := range keys {
+		if v, ok := r[key]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func single(t *testing.T, records []Record) Record {
+	t.Helper()
+	if len(records) != 1 {
+		t.Fatalf("got %d records %v, want 1", len(records), records)
+	}
+	return records[0]
+}
+
+// isTime accepts RFC 3339 strings, with or without fractional seconds.
+func isTime(v any, want time.Time) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	got, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil && got.Equal(want)
+}
+
+// isDuration accepts a time.Duration string or a number of nanoseconds,
+// milliseconds or seconds, the units used by the common backends.
+func isDuration(v any, want time.Duration) bool {
+	switch v := v.(type) {
+	case string:
+		got, err := time.ParseDuration(v)
+		return err == nil && got == want
+	case json.Number:
+		got, err := v.Float64()
+		if err != nil {
+			return false
+		}
+		for _, unit := range []time.Duration{time.Nanosecond, time.Millisecond, time.Second} {
+			if got == float64(want)/float64(unit) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonEqual compares a decoded value with the JSON text want, ignoring key
+// order and number formatting.
+func jsonEqual(v any, want string) bool {
+	var got, expected any
+	if err := json.Unmarshal([]byte(mustJSON(v)), &got); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		panic(fmt.Sprintf("conformance: invalid expected JSON %q: %v", want, err))
+	}
+	return reflect.DeepEqual(got, expected)
+}
+
+func mustJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// panicked calls log and returns the value it panicked with, if any.
+func panicked(log func()) (recovered any) {
+	defer func() { recovered = recover() }()
+	log()
+	return nil
+}
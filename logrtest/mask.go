@@ -0,0 +1,38 @@
+package logrtest
+
+import (
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+)
+
+// MaskCase is an input of RunMaskRule and what the rule must make of it.
+type MaskCase struct {
+	Input string
+	Want  string
+	// Matches is how many matches the rule must report.
+	Matches int
+}
+
+// RunMaskRule checks rule against each case, in a subtest named after the
+// input, so custom rules are tested like the built-in ones:
+//
+//	logrtest.RunMaskRule(t, logr.CardRule(), []logrtest.MaskCase{
+//		{Input: "card 4111 1111 1111 1111", Want: "card **** **** **** 1111", Matches: 1},
+//		{Input: "order 4111 1111 1111 1112", Want: "order 4111 1111 1111 1112"},
+//	})
+func RunMaskRule(t *testing.T, rule logr.MaskRule, cases []MaskCase) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.Input, func(t *testing.T) {
+			got, n := rule.Mask(tc.Input)
+			if got != tc.Want {
+				t.Errorf("%s.Mask(%q) = %q, want %q", rule.Name(), tc.Input, got, tc.Want)
+			}
+			if n != tc.Matches {
+				t.Errorf("%s.Mask(%q) reported %d matches, want %d", rule.Name(), tc.Input, n, tc.Matches)
+			}
+		})
+	}
+}
@@ -0,0 +1,401 @@
+// Package logrtest provides loggers for the tests of code that logs through
+// logr.
+package logrtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+)
+
+var _ logr.Logger = (*Recorder)(nil)
+
+type (
+	// Entry is a record captured by a Recorder.
+	Entry struct {
+		Level   logr.Level
+		Message string
+		// Fields holds the fields of the logger, of the context and of the
+		// call, in that order, with lazy fields already resolved.
+		Fields logr.Fields
+		// Caller is the file:line that logged the entry.
+		Caller string
+	}
+
+	// Recorder is a logr.Logger that keeps every entry in memory so tests can
+	// assert on them. Loggers derived with WithFields or FromContext record into
+	// the same entries and share the level. Fatal and Fatalf record the entry
+	// and return instead of exiting; Panic and Panicf record it and panic. It
+	// is safe for concurrent use.
+	Recorder struct {
+		store  *store
+		fields logr.Fields
+	}
+
+	store struct {
+		mu      sync.Mutex
+		entries []Entry
+		level   *logr.AtomicLevel
+	}
+
+	ctxKey struct{}
+)
+
+// NewRecorder returns a Recorder that records every level.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		store: &store{level: logr.NewAtomicLevel(logr.LevelTrace)},
+	}
+}
+
+// Entries returns a copy of the recorded entries, oldest first.
+func (r *Recorder) Entries() []Entry {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return slices.Clone(r.store.entries)
+}
+
+// Reset discards the recorded entries.
+func (r *Recorder) Reset() {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.entries = nil
+}
+
+// FilterByLevel returns the entries recorded at level.
+func (r *Recorder) FilterByLevel(level logr.Level) []Entry {
+	return r.filter(func(e Entry) bool { return e.Level == level })
+}
+
+// FilterByMessage returns the entries whose message contains substr.
+func (r *Recorder) FilterByMessage(substr string) []Entry {
+	return r.filter(func(e Entry) bool { return strings.Contains(e.Message, substr) })
+}
+
+// FilterByField returns the entries holding a field with key and value.
+func (r *Recorder) FilterByField(key string, value any) []Entry {
+	return r.filter(func(e Entry) bool { return e.Has(logr.Field{Key: key, Value: value}) })
+}
+
+func (r *Recorder) filter(match func(e Entry) bool) []Entry {
+	var entries []Entry
+	for _, e := range r.Entries() {
+		if match(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// AssertLogged fails t unless an entry was recorded at level with a message
+// containing msgContains and every one of fields. Fields are compared by key
+// and value.
+func (r *Recorder) AssertLogged(t testing.TB, level logr.Level, msgContains string, fields ...logr.Field) bool {
+	t.Helper()
+
+	entries := r.Entries()
+	for _, e := range entries {
+		if e.Level != level || !strings.Contains(e.Message, msgContains) {
+			continue
+		}
+		if !slices.ContainsFunc(fields, func(f logr.Field) bool { return !e.Has(f) }) {
+			return true
+		}
+	}
+
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\n\t%s", e)
+	}
+	t.Errorf("no entry at level %s with message containing %q and fields %s; recorded:%s",
+		level, msgContains, formatFields(fields), b.String())
+	return false
+}
+
+// AssertNotLogged fails t if an entry was recorded at level with a message
+// containing msgContains.
+func (r *Recorder) AssertNotLogged(t testing.TB, level logr.Level, msgContains string) bool {
+	t.Helper()
+
+	for _, e := range r.Entries() {
+		if e.Level == level && strings.Contains(e.Message, msgContains) {
+			t.Errorf("unexpected entry %s", e)
+			return false
+		}
+	}
+	return true
+}
+
+// Has reports whether e holds a field with the key and value of f.
+func (e Entry) Has(f logr.Field) bool {
+	return slices.ContainsFunc(e.Fields, func(field logr.Field) bool {
+		return field.Key == f.Key && reflect.DeepEqual(field.Value, f.Value)
+	})
+}
+
+// String implements fmt.Stringer.
+func (e Entry) String() string {
+	return fmt.Sprintf("level=%s msg=%q fields=%s caller=%s", e.Level, e.Message, formatFields(e.Fields), e.Caller)
+}
+
+func formatFields(fields logr.Fields) string {
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		parts = append(parts, fmt.Sprintf("%s=%v", f.Key, f.Value))
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// Debug implements logr.Logger.
+func (r *Recorder) Debug(message string) {
+	r.record(logr.LevelDebug, message, nil)
+}
+
+// Debugf implements logr.Logger.
+func (r *Recorder) Debugf(format string, args ...interface{}) {
+	r.recordf(logr.LevelDebug, format, args)
+}
+
+// DebugContext implements logr.Logger.
+func (r *Recorder) DebugContext(ctx context.Context, message string) {
+	r.withContext(ctx).record(logr.LevelDebug, message, nil)
+}
+
+// DebugfContext implements logr.Logger.
+func (r *Recorder) DebugfContext(ctx context.Context, format string, args ...interface{}) {
+	r.withContext(ctx).recordf(logr.LevelDebug, format, args)
+}
+
+// Debugw implements logr.Logger.
+func (r *Recorder) Debugw(message string, fields ...logr.Field) {
+	r.record(logr.LevelDebug, message, fields)
+}
+
+// Trace implements logr.Logger.
+func (r *Recorder) Trace(message string) {
+	r.record(logr.LevelTrace, message, nil)
+}
+
+// Tracef implements logr.Logger.
+func (r *Recorder) Tracef(format string, args ...interface{}) {
+	r.recordf(logr.LevelTrace, format, args)
+}
+
+// Info implements logr.Logger.
+func (r *Recorder) Info(message string) {
+	r.record(logr.LevelInfo, message, nil)
+}
+
+// Infof implements logr.Logger.
+func (r *Recorder) Infof(format string, args ...interface{}) {
+	r.recordf(logr.LevelInfo, format, args)
+}
+
+// InfoContext implements logr.Logger.
+func (r *Recorder) InfoContext(ctx context.Context, message string) {
+	r.withContext(ctx).record(logr.LevelInfo, message, nil)
+}
+
+// InfofContext implements logr.Logger.
+func (r *Recorder) InfofContext(ctx context.Context, format string, args ...interface{}) {
+	r.withContext(ctx).recordf(logr.LevelInfo, format, args)
+}
+
+// Infow implements logr.Logger.
+func (r *Recorder) Infow(message string, fields ...logr.Field) {
+	r.record(logr.LevelInfo, message, fields)
+}
+
+// Warn implements logr.Logger.
+func (r *Recorder) Warn(message string) {
+	r.record(logr.LevelWarn, message, nil)
+}
+
+// Warnf implements logr.Logger.
+func (r *Recorder) Warnf(format string, args ...interface{}) {
+	r.recordf(logr.LevelWarn, format, args)
+}
+
+// WarnContext implements logr.Logger.
+func (r *Recorder) WarnContext(ctx context.Context, message string) {
+	r.withContext(ctx).record(logr.LevelWarn, message, nil)
+}
+
+// WarnfContext implements logr.Logger.
+func (r *Recorder) WarnfContext(ctx context.Context, format string, args ...interface{}) {
+	r.withContext(ctx).recordf(logr.LevelWarn, format, args)
+}
+
+// Warnw implements logr.Logger.
+func (r *Recorder) Warnw(message string, fields ...logr.Field) {
+	r.record(logr.LevelWarn, message, fields)
+}
+
+// Error implements logr.Logger.
+func (r *Recorder) Error(message string) {
+	r.record(logr.LevelError, message, nil)
+}
+
+// Errorf implements logr.Logger.
+func (r *Recorder) Errorf(format string, args ...interface{}) {
+	r.recordf(logr.LevelError, format, args)
+}
+
+// ErrorContext implements logr.Logger.
+func (r *Recorder) ErrorContext(ctx context.Context, message string) {
+	r.withContext(ctx).record(logr.LevelError, message, nil)
+}
+
+// ErrorfContext implements logr.Logger.
+func (r *Recorder) ErrorfContext(ctx context.Context, format string, args ...interface{}) {
+	r.withContext(ctx).recordf(logr.LevelError, format, args)
+}
+
+// Errorw implements logr.Logger.
+func (r *Recorder) Errorw(message string, fields ...logr.Field) {
+	r.record(logr.LevelError, message, fields)
+}
+
+// Panic implements logr.Logger. The entry is recorded and Panic panics with
+// the message, which the test can recover.
+func (r *Recorder) Panic(message string) {
+	r.record(logr.LevelPanic, message, nil)
+	panic(message)
+}
+
+// Panicf implements logr.Logger.
+func (r *Recorder) Panicf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	r.record(logr.LevelPanic, message, nil)
+	panic(message)
+}
+
+// Fatal implements logr.Logger. The entry is recorded and Fatal returns.
+func (r *Recorder) Fatal(message string) {
+	r.record(logr.LevelFatal, message, nil)
+}
+
+// Fatalf implements logr.Logger. The entry is recorded and Fatalf returns.
+func (r *Recorder) Fatalf(format string, args ...interface{}) {
+	r.recordf(logr.LevelFatal, format, args)
+}
+
+// Log implements logr.Logger. As in the adapters, LevelPanic panics after
+// recording; LevelFatal records and returns, like Fatal.
+func (r *Recorder) Log(level logr.Level, message string, fields ...logr.Field) {
+	r.record(level, message, fields)
+	if level == logr.LevelPanic {
+		panic(message)
+	}
+}
+
+// WithFields implements logr.Logger.
+func (r *Recorder) WithFields(fields ...logr.Field) logr.Logger {
+	return r.with(fields)
+}
+
+// WithField implements logr.Logger.
+func (r *Recorder) WithField(field logr.Field) logr.Logger {
+	return r.with(logr.Fields{field})
+}
+
+// ToContext implements logr.Logger.
+func (r *Recorder) ToContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey{}, r.fields)
+}
+
+// FromContext implements logr.Logger.
+func (r *Recorder) FromContext(ctx context.Context) logr.Logger {
+	return r.withContext(ctx)
+}
+
+// GetFields implements logr.Logger.
+func (r *Recorder) GetFields() logr.Fields {
+	return r.fields
+}
+
+// Level implements logr.Logger.
+func (r *Recorder) Level() logr.Level {
+	return r.store.level.Level()
+}
+
+// SetLevel implements logr.Logger.
+func (r *Recorder) SetLevel(level logr.Level) {
+	r.store.level.SetLevel(level)
+}
+
+// Enabled implements logr.Logger.
+func (r *Recorder) Enabled(level logr.Level) bool {
+	return r.store.level.Enabled(level)
+}
+
+// Output implements logr.Logger.
+func (r *Recorder) Output() io.Writer {
+	return io.Discard
+}
+
+// Sync implements logr.Logger.
+func (r *Recorder) Sync() error {
+	return nil
+}
+
+// Close implements logr.Logger.
+func (r *Recorder) Close() error {
+	return nil
+}
+
+func (r *Recorder) with(fields logr.Fields) *Recorder {
+	return &Recorder{
+		store:  r.store,
+		fields: slices.Concat(r.fields, fields),
+	}
+}
+
+// withContext returns r extended with the fields stored in ctx by ToContext.
+func (r *Recorder) withContext(ctx context.Context) *Recorder {
+	fields, _ := ctx.Value(ctxKey{}).(logr.Fields)
+	if len(fields) == 0 {
+		return r
+	}
+	return r.with(fields)
+}
+
+// recordf formats the message only for enabled levels. Like record, it must be
+// called straight from the logging method for Caller to be right.
+func (r *Recorder) recordf(level logr.Level, format string, args []interface{}) {
+	if r.Enabled(level) {
+		r.add(level, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+func (r *Recorder) record(level logr.Level, message string, fields logr.Fields) {
+	if r.Enabled(level) {
+		r.add(level, message, fields)
+	}
+}
+
+func (r *Recorder) add(level logr.Level, message string, fields logr.Fields) {
+	entry := Entry{
+		Level:   level,
+		Message: message,
+		Fields:  logr.Resolve(slices.Concat(r.fields, fields)),
+	}
+	// add <- record/recordf <- método de log <- chamador
+	if _, file, line, ok := runtime.Caller(3); ok {
+		entry.Caller = filepath.Base(file) + ":" + strconv.Itoa(line)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.entries = append(r.store.entries, entry)
+}
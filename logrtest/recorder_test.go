@@ -0,0 +1,102 @@
+package logrtest_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/logrtest"
+)
+
+func TestRecorder(t *testing.T) {
+	r := logrtest.NewRecorder()
+	r.SetLevel(logr.LevelInfo)
+
+	calls := 0
+	lazy := logr.Lazy("lazy", func() any {
+		calls++
+		return "resolved"
+	})
+
+	l := r.WithFields(logr.String("service", "orders"))
+	ctx := l.WithFields(logr.String("request_id", "r1")).ToContext(context.Background())
+
+	l.Debugw("hidden", lazy)
+	l.Infow("order created", logr.Int("items", 3), lazy)
+	r.WarnfContext(ctx, "slow %s", "query")
+	l.Fatal("still running")
+
+	if calls != 1 {
+		t.Errorf("lazy field resolved %d times, want 1", calls)
+	}
+
+	r.AssertLogged(t, logr.LevelInfo, "created", logr.String("service", "orders"), logr.Int("items", 3), logr.String("lazy", "resolved"))
+	r.AssertLogged(t, logr.LevelWarn, "slow query", logr.String("service", "orders"), logr.String("request_id", "r1"))
+	r.AssertLogged(t, logr.LevelFatal, "still running")
+	r.AssertNotLogged(t, logr.LevelDebug, "hidden")
+
+	if got := len(r.FilterByField("request_id", "r1")); got != 1 {
+		t.Errorf("FilterByField(request_id) = %d entries, want 1", got)
+	}
+
+	entries := r.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if !strings.HasPrefix(entries[0].Caller, "recorder_test.go:") {
+		t.Errorf("Caller = %q, want recorder_test.go:<line>", entries[0].Caller)
+	}
+
+	r.Reset()
+	if got := len(l.(*logrtest.Recorder).Entries()); got != 0 {
+		t.Errorf("derived logger has %d entries after Reset, want 0", got)
+	}
+}
+
+func TestRecorderAssertLoggedFails(t *testing.T) {
+	r := logrtest.NewRecorder()
+	r.Info("other")
+
+	ft := &fakeT{}
+	if r.AssertLogged(ft, logr.LevelWarn, "missing") || !ft.failed {
+		t.Error("AssertLogged passed without a matching entry")
+	}
+	// os níveis aparecem pelo nome, não pelo número
+	if !strings.Contains(ft.message, "at level WARN") || !strings.Contains(ft.message, "level=INFO msg=\"other\"") {
+		t.Errorf("failure message = %q, want the levels by name", ft.message)
+	}
+}
+
+func TestRecorderConcurrent(t *testing.T) {
+	r := logrtest.NewRecorder()
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.WithFields(logr.Int("worker", i)).Info("done")
+		}()
+	}
+	wg.Wait()
+
+	if got := len(r.FilterByMessage("done")); got != 10 {
+		t.Errorf("got %d entries, want 10", got)
+	}
+}
+
+type fakeT struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.failed = true
+	f.message = fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,458 @@
+package logrtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+)
+
+var _ logr.Logger = (*tLogger)(nil)
+
+// tLogger writes every record through t.Log, so it shows only for failing
+// tests (or with go test -v) next to the output of the test itself.
+type tLogger struct {
+	t      testing.TB
+	fields logr.Fields
+	level  *logr.AtomicLevel
+}
+
+// NewT returns a logr.Logger that writes through t.Log, reporting the file
+// and line of the code that logged. Records are rendered like the TEXT
+// formatter, without the time. Fatal and Fatalf end the test with t.Fatal;
+// Panic and Panicf log the record and panic.
+func NewT(t testing.TB) logr.Logger {
+	return &tLogger{
+		t:     t,
+		level: logr.NewAtomicLevel(logr.LevelTrace),
+	}
+}
+
+// Debug implements logr.Logger.
+func (l *tLogger) Debug(message string) {
+	l.t.Helper()
+	l.log(logr.LevelDebug, message, nil)
+}
+
+// Debugf implements logr.Logger.
+func (l *tLogger) Debugf(format string, args ...interface{}) {
+	l.t.Helper()
+	l.logf(logr.LevelDebug, format, args)
+}
+
+// DebugContext implements logr.Logger.
+func (l *tLogger) DebugContext(ctx context.Context, message string) {
+	l.t.Helper()
+	l.withContext(ctx).log(logr.LevelDebug, message, nil)
+}
+
+// DebugfContext implements logr.Logger.
+func (l *tLogger) DebugfContext(ctx context.Context, format string, args ...interface{}) {
+	l.t.Helper()
+	l.withContext(ctx).logf(logr.LevelDebug, format, args)
+}
+
+// Debugw implements logr.Logger.
+func (l *tLogger) Debugw(message string, fields ...logr.Field) {
+	l.t.Helper()
+	l.log(logr.LevelDebug, message, fields)
+}
+
+// Trace implements logr.Logger.
+func (l *tLogger) Trace(message string) {
+	l.t.Helper()
+	l.log(logr.LevelTrace, message, nil)
+}
+
+// Tracef implements logr.Logger.
+func (l *tLogger) Tracef(format string, args ...interface{}) {
+	l.t.Helper()
+	l.logf(logr.LevelTrace, format, args)
+}
+
+// Info implements logr.Logger.
+func (l *tLogger) Info(message string) {
+	l.t.Helper()
+	l.log(logr.LevelInfo, message, nil)
+}
+
+// Infof implements logr.Logger.
+func (l *tLogger) Infof(format string, args ...interface{}) {
+	l.t.Helper()
+	l.logf(logr.LevelInfo, format, args)
+}
+
+// InfoContext implements logr.Logger.
+func (l *tLogger) InfoContext(ctx context.Context, message string) {
+	l.t.Helper()
+	l.withContext(ctx).log(logr.LevelInfo, message, nil)
+}
+
+// InfofContext implements logr.Logger.
+func (l *tLogger) InfofContext(ctx context.Context, format string, args ...interface{}) {
+	l.t.Helper()
+	l.withContext(ctx).logf(logr.LevelInfo, format, args)
+}
+
+// Infow implements logr.Logger.
+func (l *tLogger) Infow(message string, fields ...logr.Field) {
+	l.t.Helper()
+	l.log(logr.LevelInfo, message, fields)
+}
+
+// Warn implements logr.Logger.
+func (l *tLogger) Warn(message string) {
+	l.t.Helper()
+	l.log(logr.LevelWarn, message, nil)
+}
+
+// Warnf implements logr.Logger.
+func (l *tLogger) Warnf(format string, args ...interface{}) {
+	l.t.Helper()
+	l.logf(logr.LevelWarn, format, args)
+}
+
+// WarnContext implements logr.Logger.
+func (l *tLogger) WarnContext(ctx context.Context, message string) {
+	l.t.Helper()
+	l.withContext(ctx).log(logr.LevelWarn, message, nil)
+}
+
+// WarnfContext implements logr.Logger.
+func (l *tLogger) WarnfContext(ctx context.Context, format string, args ...interface{}) {
+	l.t.Helper()
+	l.withContext(ctx).logf(logr.LevelWarn, format, args)
+}
+
+// Warnw implements logr.Logger.
+func (l *tLogger) Warnw(message string, fields ...logr.Field) {
+	l.t.Helper()
+	l.log(logr.LevelWarn, message, fields)
+}
+
+// Error implements logr.Logger.
+func (l *tLogger) Error(message string) {
+	l.t.Helper()
+	l.log(logr.LevelError, message, nil)
+}
+
+// Errorf implements logr.Logger.
+func (l *tLogger) Errorf(format string, args ...interface{}) {
+	l.t.Helper()
+	l.logf(logr.LevelError, format, args)
+}
+
+// ErrorContext implements logr.Logger.
+func (l *tLogger) ErrorContext(ctx context.Context, message string) {
+	l.t.Helper()
+	l.withContext(ctx).log(logr.LevelError, message, nil)
+}
+
+// ErrorfContext implements logr.Logger.
+func (l *tLogger) ErrorfContext(ctx context.Context, format string, args ...interface{}) {
+	l.t.Helper()
+	l.withContext(ctx).logf(logr.LevelError, format, args)
+}
+
+// Errorw implements logr.Logger.
+func (l *tLogger) Errorw(message string, fields ...logr.Field) {
+	l.t.Helper()
+	l.log(logr.LevelError, message, fields)
+}
+
+// Panic implements logr.Logger. The record is logged and Panic panics with
+// the message.
+func (l *tLogger) Panic(message string) {
+	l.t.Helper()
+	l.t.Log(l.format(logr.LevelPanic, message, nil))
+	panic(message)
+}
+
+// Panicf implements logr.Logger.
+func (l *tLogger) Panicf(format string, args ...interface{}) {
+	l.t.Helper()
+	message := fmt.Sprintf(format, args...)
+	l.t.Log(l.format(logr.LevelPanic, message, nil))
+	panic(message)
+}
+
+// Fatal implements logr.Logger.
+func (l *tLogger) Fatal(message string) {
+	l.t.Helper()
+	l.t.Fatal(l.format(logr.LevelFatal, message, nil))
+}
+
+// Fatalf implements logr.Logger.
+func (l *tLogger) Fatalf(format string, args ...interface{}) {
+	l.t.Helper()
+	l.t.Fatal(l.format(logr.LevelFatal, fmt.Sprintf(format, args...), nil))
+}
+
+// Log implements logr.Logger. LevelPanic and LevelFatal behave as Panic and
+// Fatal.
+func (l *tLogger) Log(level logr.Level, message string, fields ...logr.Field) {
+	l.t.Helper()
+	switch level {
+	case logr.LevelPanic:
+		l.t.Log(l.format(level, message, fields))
+		panic(message)
+	case logr.LevelFatal:
+		l.t.Fatal(l.format(level, message, fields))
+	}
+	l.log(level, message, fields)
+}
+
+// WithFields implements logr.Logger.
+func (l *tLogger) WithFields(fields ...logr.Field) logr.Logger {
+	return l.with(fields)
+}
+
+// WithField implements logr.Logger.
+func (l *tLogger) WithField(field logr.Field) logr.Logger {
+	return l.with(logr.Fields{field})
+}
+
+// ToContext implements logr.Logger.
+func (l *tLogger) ToContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l.fields)
+}
+
+// FromContext implements logr.Logger.
+func (l *tLogger) FromContext(ctx context.Context) logr.Logger {
+	return l.withContext(ctx)
+}
+
+// GetFields implements logr.Logger.
+func (l *tLogger) GetFields() logr.Fields {
+	return l.fields
+}
+
+// Level implements logr.Logger.
+func (l *tLogger) Level() logr.Level {
+	return l.level.Level()
+}
+
+// SetLevel implements logr.Logger.
+func (l *tLogger) SetLevel(level logr.Level) {
+	l.level.SetLevel(level)
+}
+
+// Enabled implements logr.Logger.
+func (l *tLogger) Enabled(level logr.Level) bool {
+	return l.level.Enabled(level)
+}
+
+// Output implements logr.Logger.
+func (l *tLogger) Output() io.Writer {
+	return tWriter{t: l.t}
+}
+
+// Sync implements logr.Logger.
+func (l *tLogger) Sync() error {
+	return nil
+}
+
+// Close implements logr.Logger.
+func (l *tLogger) Close() error {
+	return nil
+}
+
+func (l *tLogger) with(fields logr.Fields) *tLogger {
+	return &tLogger{
+		t:      l.t,
+		fields: slices.Concat(l.fields, fields),
+		level:  l.level,
+	}
+}
+
+// withContext returns l extended with the fields stored in ctx by ToContext.
+func (l *tLogger) withContext(ctx context.Context) *tLogger {
+	fields, _ := ctx.Value(ctxKey{}).(logr.Fields)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.with(fields)
+}
+
+func (l *tLogger) logf(level logr.Level, format string, args []interface{}) {
+	l.t.Helper()
+	if l.Enabled(level) {
+		l.t.Log(l.format(level, fmt.Sprintf(format, args...), nil))
+	}
+}
+
+func (l *tLogger) log(level logr.Level, message string, fields logr.Fields) {
+	l.t.Helper()
+	if l.Enabled(level) {
+		l.t.Log(l.format(level, message, fields))
+	}
+}
+
+// format renders the record with slog's text handler, the same used by the
+// TEXT formatter. The zero time leaves out the time, which t.Log does not need.
+func (l *tLogger) format(level logr.Level, message string, fields logr.Fields) string {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: toSlogLevel(logr.LevelTrace),
+		// slog não conhece TRACE, PANIC e FATAL; escreve o nome do logr
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey && len(groups) == 0 {
+				a.Value = slog.StringValue(level.String())
+			}
+			return a
+		},
+	})
+
+	record := slog.NewRecord(time.Time{}, toSlogLevel(level), logr.MaskMessage(message), 0)
+	for _, f := range logr.RedactFields(logr.Resolve(slices.Concat(l.fields, fields))) {
+		record.AddAttrs(attrs(f)...)
+	}
+	_ = handler.Handle(context.Background(), record)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// tWriter sends each write to t.Log.
+type tWriter struct {
+	t testing.TB
+}
+
+func (w tWriter) Write(p []byte) (int, error) {
+	w.t.Helper()
+	w.t.Log(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+func toSlogLevel(level logr.Level) slog.Level {
+	switch level {
+	case logr.LevelTrace:
+		return slog.LevelDebug - 4
+	case logr.LevelDebug:
+		return slog.LevelDebug
+	case logr.LevelInfo:
+		return slog.LevelInfo
+	case logr.LevelWarn:
+		return slog.LevelWarn
+	case logr.LevelError:
+		return slog.LevelError
+	case logr.LevelPanic:
+		return slog.LevelError + 4
+	case logr.LevelFatal:
+		return slog.LevelError + 8
+	}
+	return slog.LevelInfo
+}
+
+// attrs maps f to slog attrs as the slog adapter does; errors also add their
+// chain and verbose rendering.
+func attrs(f logr.Field) []slog.Attr {
+	if err, ok := f.Value.(error); ok && f.Type == logr.ErrorType {
+		result := []slog.Attr{
+			slog.String(f.Key, err.Error()),
+			slog.Any(f.Key+logr.ErrorChainSuffix, logr.ErrorChain(err)),
+		}
+		if verbose := logr.ErrorVerbose(err); verbose != "" {
+			result = append(result, slog.String(f.Key+logr.ErrorVerboseSuffix, verbose))
+		}
+		return result
+	}
+	return []slog.Attr{attr(f)}
+}
+
+func attr(f logr.Field) slog.Attr {
+	switch v := f.Value.(type) {
+	case float32:
+		// mesma conversão do adapter slog, que não expõe a imprecisão do float32
+		v64, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
+		return slog.Float64(f.Key, v64)
+	case []byte:
+		if f.Type == logr.HexType {
+			return slog.String(f.Key, hex.EncodeToString(v))
+		}
+		return slog.String(f.Key, base64.StdEncoding.EncodeToString(v))
+	case fmt.Stringer:
+		if f.Type == logr.StringerType {
+			return slog.String(f.Key, fmt.Sprint(v))
+		}
+	case []logr.Field:
+		if f.Type == logr.GroupType {
+			group := make([]any, 0, len(v))
+			for _, gf := range v {
+				for _, a := range attrs(gf) {
+					group = append(group, a)
+				}
+			}
+			return slog.Group(f.Key, group...)
+		}
+	case logr.ObjectMarshaler:
+		if f.Type == logr.ObjectType {
+			enc := &objectEncoder{}
+			if err := v.MarshalLogObject(enc); err != nil {
+				enc.AddString(logr.ErrorKey, err.Error())
+			}
+			return slog.Attr{Key: f.Key, Value: slog.GroupValue(enc.attrs...)}
+		}
+	}
+	return slog.Any(f.Key, f.Value)
+}
+
+// objectEncoder collects the fields of a logr.ObjectMarshaler as slog attrs.
+type objectEncoder struct {
+	attrs []slog.Attr
+}
+
+func (e *objectEncoder) AddString(key, value string) {
+	e.attrs = append(e.attrs, slog.String(key, value))
+}
+
+func (e *objectEncoder) AddBool(key string, value bool) {
+	e.attrs = append(e.attrs, slog.Bool(key, value))
+}
+
+func (e *objectEncoder) AddInt(key string, value int) {
+	e.attrs = append(e.attrs, slog.Int(key, value))
+}
+
+func (e *objectEncoder) AddInt64(key string, value int64) {
+	e.attrs = append(e.attrs, slog.Int64(key, value))
+}
+
+func (e *objectEncoder) AddUint64(key string, value uint64) {
+	e.attrs = append(e.attrs, slog.Uint64(key, value))
+}
+
+func (e *objectEncoder) AddFloat64(key string, value float64) {
+	e.attrs = append(e.attrs, slog.Float64(key, value))
+}
+
+func (e *objectEncoder) AddTime(key string, value time.Time) {
+	e.attrs = append(e.attrs, slog.Time(key, value))
+}
+
+func (e *objectEncoder) AddDuration(key string, value time.Duration) {
+	e.attrs = append(e.attrs, slog.Duration(key, value))
+}
+
+func (e *objectEncoder) AddStrings(key string, value []string) {
+	e.attrs = append(e.attrs, slog.Any(key, value))
+}
+
+func (e *objectEncoder) AddObject(key string, value logr.ObjectMarshaler) error {
+	enc := &objectEncoder{}
+	err := value.MarshalLogObject(enc)
+	e.attrs = append(e.attrs, slog.Attr{Key: key, Value: slog.GroupValue(enc.attrs...)})
+	return err
+}
+
+func (e *objectEncoder) AddAny(key string, value any) {
+	e.attrs = append(e.attrs, slog.Any(key, value))
+}
@@ -0,0 +1,59 @@
+package logrtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/logrtest"
+)
+
+func TestNewT(t *testing.T) {
+	ft := &logT{}
+	l := logrtest.NewT(ft)
+	l.SetLevel(logr.LevelInfo)
+
+	ctx := l.WithFields(logr.String("request_id", "r1")).ToContext(context.Background())
+
+	l.Debug("hidden")
+	l.WithFields(logr.Group("http", logr.Int("status", 200))).Infow("request done", logr.String("path", "/a b"), logr.Float32("ratio", 0.1))
+	l.WarnfContext(ctx, "retry %d", 2)
+	l.Errorw("failed", logr.Err(errors.New("boom")))
+	l.Fatalf("giving up after %d", 3)
+
+	want := []string{
+		`level=INFO msg="request done" http.status=200 path="/a b" ratio=0.1`,
+		`level=WARN msg="retry 2" request_id=r1`,
+		`level=ERROR msg=failed error=boom errorChain="[{Message:boom Type:*errors.errorString}]"`,
+	}
+	if len(ft.logs) != len(want) {
+		t.Fatalf("got %d lines %q, want %d", len(ft.logs), ft.logs, len(want))
+	}
+	for i := range want {
+		if ft.logs[i] != want[i] {
+			t.Errorf("line %d = %s, want %s", i, ft.logs[i], want[i])
+		}
+	}
+	if want := `level=FATAL msg="giving up after 3"`; ft.fatal != want {
+		t.Errorf("Fatal = %q, want %q", ft.fatal, want)
+	}
+}
+
+// logT captures what the logger sends to testing.TB.
+type logT struct {
+	testing.TB
+	logs  []string
+	fatal string
+}
+
+func (l *logT) Helper() {}
+
+func (l *logT) Log(args ...any) {
+	l.logs = append(l.logs, fmt.Sprint(args...))
+}
+
+func (l *logT) Fatal(args ...any) {
+	l.fatal = fmt.Sprint(args...)
+}
@@ -0,0 +1,397 @@
+package logr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+type (
+	// MaskRule finds one kind of personal data in text and masks it.
+	MaskRule interface {
+		// Name identifies the rule in Masker.Counts.
+		Name() string
+		// Mask returns s with the matches masked and how many there were.
+		Mask(s string) (string, int)
+	}
+
+	// Masker runs its rules, in order, over the messages and the field values
+	// the adapters write as text (see SetMasker), counting the matches of each rule. It is safe
+	// for concurrent use.
+	Masker struct {
+		rules  []MaskRule
+		counts []atomic.Uint64
+	}
+)
+
+// maskerPolicy is nil until SetMasker, when nothing is masked.
+var maskerPolicy atomic.Pointer[Masker]
+
+// NewMasker returns a Masker running rules in order.
+func NewMasker(rules ...MaskRule) *Masker {
+	return &Masker{rules: rules, counts: make([]atomic.Uint64, len(rules))}
+}
+
+// DefaultMaskRules returns the built-in rules: EmailRule, CardRule, CPFRule
+// and IPRule, in that order.
+func DefaultMaskRules() []MaskRule {
+	return []MaskRule{EmailRule(), CardRule(), CPFRule(), IPRule()}
+}
+
+// SetMasker makes every adapter mask with m the messages, formatted ones
+// included, and the field values rendered as text, at any depth of Group
+// fields: String and Strings values, the message, chain and verbose rendering
+// of errors, Stringer values and Any and Lazy values. An Any value in which
+// something is masked is logged as its JSON rendering decoded, so maps and
+// structs become maps. The values added by an ObjectMarshaler are not masked.
+// A nil m turns masking off.
+func SetMasker(m *Masker) {
+	maskerPolicy.Store(m)
+}
+
+// GetMasker returns the Masker given to SetMasker, nil when there is none.
+func GetMasker() *Masker {
+	return maskerPolicy.Load()
+}
+
+// MaskMessage returns message masked by the Masker given to SetMasker. The
+// adapters call it on every message they write.
+func MaskMessage(message string) string {
+	if m := maskerPolicy.Load(); m != nil {
+		return m.Mask(message)
+	}
+	return message
+}
+
+// Mask returns s with every rule applied.
+func (m *Masker) Mask(s string) string {
+	for i, rule := range m.rules {
+		masked, n := rule.Mask(s)
+		if n > 0 {
+			m.counts[i].Add(uint64(n))
+			s = masked
+		}
+	}
+	return s
+}
+
+// Counts returns how many matches each rule has masked so far, by Name.
+func (m *Masker) Counts() map[string]uint64 {
+	counts := make(map[string]uint64, len(m.rules))
+	for i, rule := range m.rules {
+		counts[rule.Name()] += m.counts[i].Load()
+	}
+	return counts
+}
+
+// maskField masks the values of f the adapters render as text: String and
+// Strings values, the message, chain and verbose rendering of an ErrorType
+// field, a Stringer once rendered and an Any value, Lazy ones included once
+// resolved, rendered as JSON. It reports whether f changed.
+func maskField(m *Masker, f Field) (Field, bool) {
+	switch f.Type {
+	case StringType, StringsType:
+		return maskStrings(m, f)
+	case ErrorType:
+		if err, ok := f.Value.(error); ok {
+			if masked, changed := newMaskedError(m, err); changed {
+				return NamedErr(f.Key, masked), true
+			}
+		}
+	case StringerType:
+		if v, ok := f.Value.(fmt.Stringer); ok {
+			s := fmt.Sprint(v)
+			if masked := m.Mask(s); masked != s {
+				return String(f.Key, masked), true
+			}
+		}
+	case AnyType:
+		if masked, changed := maskAny(m, f.Value); changed {
+			return Any(f.Key, masked), true
+		}
+	}
+	return f, false
+}
+
+// maskStrings masks the value of a String or Strings field, reporting
+// whether it changed.
+func maskStrings(m *Masker, f Field) (Field, bool) {
+	switch v := f.Value.(type) {
+	case string:
+		if masked := m.Mask(v); masked != v {
+			return String(f.Key, masked), true
+		}
+	case []string:
+		var masked []string
+		for i, s := range v {
+			ms := m.Mask(s)
+			if ms != s && masked == nil {
+				masked = append([]string(nil), v...)
+			}
+			if masked != nil {
+				masked[i] = ms
+			}
+		}
+		if masked != nil {
+			return Strings(f.Key, masked), true
+		}
+	}
+	return f, false
+}
+
+// maskAny masks the strings of value as the JSON encoders render it. A value
+// with a match is returned decoded from that JSON, with the masked strings,
+// and one that is not JSON is rendered with fmt.Sprint.
+func maskAny(m *Masker, value any) (any, bool) {
+	if s, ok := value.(string); ok {
+		masked := m.Mask(s)
+		return masked, masked != s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		s := fmt.Sprint(value)
+		masked := m.Mask(s)
+		return masked, masked != s
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	// números continuam números, a menos que sejam mascarados
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return value, false
+	}
+	if masked, changed := maskDecoded(m, decoded); changed {
+		return masked, true
+	}
+	return value, false
+}
+
+// maskDecoded masks, in place, the strings and numbers of a value decoded
+// from JSON.
+func maskDecoded(m *Masker, v any) (any, bool) {
+	switch v := v.(type) {
+	case string:
+		masked := m.Mask(v)
+		return masked, masked != v
+	case json.Number:
+		if masked := m.Mask(v.String()); masked != v.String() {
+			return masked, true
+		}
+	case []any:
+		changed := false
+		for i, e := range v {
+			if masked, ok := maskDecoded(m, e); ok {
+				v[i], changed = masked, true
+			}
+		}
+		return v, changed
+	case map[string]any:
+		changed := false
+		for k, e := range v {
+			if masked, ok := maskDecoded(m, e); ok {
+				v[k], changed = masked, true
+			}
+		}
+		return v, changed
+	}
+	return v, false
+}
+
+// maskedError is an error rendered with its message, chain and verbose
+// rendering masked. The masking is done once, when the field is redacted, so
+// the counts of the Masker do not depend on how often an adapter renders it.
+type maskedError struct {
+	err     error
+	message string
+	chain   []ErrorLink
+	verbose string
+}
+
+// newMaskedError masks err, reporting whether anything in it changed.
+func newMaskedError(m *Masker, err error) (maskedError, bool) {
+	e := maskedError{err: err, message: m.Mask(err.Error())}
+	changed := e.message != err.Error()
+	e.chain = ErrorChain(err)
+	for i := range e.chain {
+		// o primeiro elo é o próprio err
+		masked := e.message
+		if i > 0 {
+			masked = m.Mask(e.chain[i].Message)
+		}
+		changed = changed || masked != e.chain[i].Message
+		e.chain[i].Message = masked
+	}
+	if verbose := ErrorVerbose(err); verbose != "" {
+		e.verbose = m.Mask(verbose)
+		changed = changed || e.verbose != verbose
+	}
+	return e, changed
+}
+
+func (e maskedError) Error() string {
+	return e.message
+}
+
+func (e maskedError) Unwrap() error {
+	return e.err
+}
+
+// Format renders the masked verbose rendering under "%+v", for ErrorVerbose
+// and the adapters that look for it, and the masked message otherwise.
+func (e maskedError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') && e.verbose != "" {
+		_, _ = io.WriteString(s, e.verbose)
+		return
+	}
+	_, _ = io.WriteString(s, e.message)
+}
+
+// funcRule is a MaskRule whose matches are found by re and masked by mask,
+// which reports false to leave a match alone.
+type funcRule struct {
+	name string
+	re   *regexp.Regexp
+	mask func(match string) (string, bool)
+}
+
+func (r funcRule) Name() string {
+	return r.name
+}
+
+func (r funcRule) Mask(s string) (string, int) {
+	n := 0
+	masked := r.re.ReplaceAllStringFunc(s, func(match string) string {
+		if m, ok := r.mask(match); ok {
+			n++
+			return m
+		}
+		return match
+	})
+	return masked, n
+}
+
+// RegexRule masks every match of re with replacement, which may refer to
+// the submatches as in regexp.Regexp.Expand.
+func RegexRule(name string, re *regexp.Regexp, replacement string) MaskRule {
+	return funcRule{name: name, re: re, mask: func(match string) (string, bool) {
+		return re.ReplaceAllString(match, replacement), true
+	}}
+}
+
+var (
+	cardPattern  = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	emailPattern = regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)
+	cpfPattern   = regexp.MustCompile(`\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b`)
+	ipv4Pattern  = regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`)
+)
+
+// CardRule masks payment card numbers, of 13 to 19 digits optionally
+// grouped by spaces or dashes, that pass the Luhn check, keeping the last 4
+// digits: "**** **** **** 1111".
+func CardRule() MaskRule {
+	return funcRule{name: "card", re: cardPattern, mask: func(match string) (string, bool) {
+		digits := onlyDigits(match)
+		if !luhn(digits) {
+			return "", false
+		}
+		return maskDigits(match, len(digits)-4), true
+	}}
+}
+
+// EmailRule masks e-mail addresses keeping the first character of the local
+// part and the domain: "a***@example.com".
+func EmailRule() MaskRule {
+	return funcRule{name: "email", re: emailPattern, mask: func(match string) (string, bool) {
+		at := strings.LastIndexByte(match, '@')
+		return match[:1] + "***" + match[at:], true
+	}}
+}
+
+// CPFRule masks CPFs, formatted or not, whose check digits are valid:
+// "***.***.***-**".
+func CPFRule() MaskRule {
+	return funcRule{name: "cpf", re: cpfPattern, mask: func(match string) (string, bool) {
+		if !validCPF(onlyDigits(match)) {
+			return "", false
+		}
+		return maskDigits(match, 11), true
+	}}
+}
+
+// IPRule masks the last octet of IPv4 addresses: "192.168.0.***".
+func IPRule() MaskRule {
+	return funcRule{name: "ip", re: ipv4Pattern, mask: func(match string) (string, bool) {
+		if _, err := netip.ParseAddr(match); err != nil {
+			return "", false
+		}
+		dot := strings.LastIndexByte(match, '.')
+		return match[:dot+1] + "***", true
+	}}
+}
+
+func onlyDigits(s string) []byte {
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if '0' <= s[i] && s[i] <= '9' {
+			digits = append(digits, s[i]-'0')
+		}
+	}
+	return digits
+}
+
+// maskDigits replaces the first n digits of s with "*", keeping the
+// separators.
+func maskDigits(s string, n int) string {
+	b := []byte(s)
+	for i := 0; i < len(b) && n > 0; i++ {
+		if '0' <= b[i] && b[i] <= '9' {
+			b[i] = '*'
+			n--
+		}
+	}
+	return string(b)
+}
+
+func luhn(digits []byte) bool {
+	sum := 0
+	for i := range digits {
+		d := int(digits[len(digits)-1-i])
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+func validCPF(digits []byte) bool {
+	if len(digits) != 11 {
+		return false
+	}
+	// sequências repetidas passam na conta, mas não são CPFs
+	same := true
+	for _, d := range digits[1:] {
+		same = same && d == digits[0]
+	}
+	if same {
+		return false
+	}
+	for n := 9; n <= 10; n++ {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(digits[i]) * (n + 1 - i)
+		}
+		check := sum * 10 % 11 % 10
+		if check != int(digits[n]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package logr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/logrtest"
+)
+
+func TestMaskRules(t *testing.T) {
+	t.Run("card", func(t *testing.T) {
+		logrtest.RunMaskRule(t, logr.CardRule(), []logrtest.MaskCase{
+			{Input: "card 4111 1111 1111 1111", Want: "card **** **** **** 1111", Matches: 1},
+			{Input: "card 5500-0000-0000-0004 ok", Want: "card ****-****-****-0004 ok", Matches: 1},
+			{Input: "order 4111111111111112", Want: "order 4111111111111112"},
+		})
+	})
+	t.Run("email", func(t *testing.T) {
+		logrtest.RunMaskRule(t, logr.EmailRule(), []logrtest.MaskCase{
+			{Input: "sent to ana.silva@example.com", Want: "sent to a***@example.com", Matches: 1},
+			{Input: "no address here", Want: "no address here"},
+		})
+	})
+	t.Run("cpf", func(t *testing.T) {
+		logrtest.RunMaskRule(t, logr.CPFRule(), []logrtest.MaskCase{
+			{Input: "cpf 529.982.247-25", Want: "cpf ***.***.***-**", Matches: 1},
+			{Input: "cpf 52998224725", Want: "cpf ***********", Matches: 1},
+			{Input: "id 529.982.247-26", Want: "id 529.982.247-26"},
+			{Input: "id 111.111.111-11", Want: "id 111.111.111-11"},
+		})
+	})
+	t.Run("ip", func(t *testing.T) {
+		logrtest.RunMaskRule(t, logr.IPRule(), []logrtest.MaskCase{
+			{Input: "from 192.168.0.17", Want: "from 192.168.0.***", Matches: 1},
+			{Input: "version 1.300.2.4", Want: "version 1.300.2.4"},
+		})
+	})
+	t.Run("regex", func(t *testing.T) {
+		rule := logr.RegexRule("phone", regexp.MustCompile(`\+55 (\d{2}) \d{4,5}-\d{4}`), "+55 $1 *****-****")
+		logrtest.RunMaskRule(t, rule, []logrtest.MaskCase{
+			{Input: "call +55 11 91234-5678", Want: "call +55 11 *****-****", Matches: 1},
+		})
+	})
+}
+
+func TestMaskerCounts(t *testing.T) {
+	m := logr.NewMasker(logr.DefaultMaskRules()...)
+	logr.SetMasker(m)
+	t.Cleanup(func() { logr.SetMasker(nil) })
+
+	got := logr.MaskMessage("ana@example.com paid with 4111 1111 1111 1111 from 10.0.0.1 and 10.0.0.2")
+	if want := "a***@example.com paid with **** **** **** 1111 from 10.0.0.*** and 10.0.0.***"; got != want {
+		t.Errorf("MaskMessage() = %q, want %q", got, want)
+	}
+
+	f := logr.Redact(logr.Group("user", logr.String("email", "ana@example.com")))
+	if group, _ := f.Value.([]logr.Field); len(group) != 1 || group[0].Value != "a***@example.com" {
+		t.Errorf("Redact(Group) = %v, want the email masked", f.Value)
+	}
+
+	counts := m.Counts()
+	for rule, want := range map[string]uint64{"email": 2, "card": 1, "cpf": 0, "ip": 2} {
+		if counts[rule] != want {
+			t.Errorf("Counts()[%q] = %d, want %d", rule, counts[rule], want)
+		}
+	}
+}
+
+func TestMaskRenderedValues(t *testing.T) {
+	masker := logr.GetMasker()
+	t.Cleanup(func() { logr.SetMasker(masker) })
+	logr.SetMasker(logr.NewMasker(logr.EmailRule()))
+
+	err := fmt.Errorf("notify ana@example.com: %w", errors.New("bob@example.com refused"))
+	f := logr.Redact(logr.Err(err))
+	masked, _ := f.Value.(error)
+	if f.Type != logr.ErrorType || masked == nil || masked.Error() != "notify a***@example.com: b***@example.com refused" {
+		t.Fatalf("Redact(Err) = %+v, want the message masked", f)
+	}
+	if !errors.Is(masked, err) {
+		t.Error("the masked error does not wrap the original one")
+	}
+	chain := logr.ErrorChain(masked)
+	if len(chain) != 2 || chain[1].Message != "b***@example.com refused" || chain[1].Type != "*errors.errorString" {
+		t.Errorf("chain = %+v, want the messages masked and the types kept", chain)
+	}
+	if plain := logr.Err(errors.New("refused")); logr.Redact(plain) != plain {
+		t.Error("Redact changed an error with nothing to mask")
+	}
+
+	resolved := logr.Resolve(logr.Fields{
+		logr.Stringer("to", stringer("ana@example.com")),
+		logr.Any("user", map[string]any{"email": "ana@example.com", "age": 30}),
+		logr.Lazy("from", func() any { return []string{"bob@example.com"} }),
+	})
+	want := []string{`"a***@example.com"`, `{"age":30,"email":"a***@example.com"}`, `["b***@example.com"]`}
+	for i, f := range logr.RedactFields(resolved) {
+		if got, _ := json.Marshal(f.Value); string(got) != want[i] {
+			t.Errorf("%s = %s, want %s", f.Key, got, want[i])
+		}
+	}
+}
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
@@ -2,12 +2,18 @@ package logr
 
 import (
 	"context"
+	"fmt"
 	"io"
 )
 
 var _ Logger = Noop{}
 
-type Noop struct{}
+// Noop discards every record. Panic and Panicf still panic with the message,
+// masked as by the adapters, and Fatal and Fatalf still end the process like
+// the adapters do, through OnFatal or, when it is nil, Exit(1).
+type Noop struct {
+	OnFatal func()
+}
 
 // Debug implements Logger.
 func (n Noop) Debug(message string) {}
@@ -15,17 +21,44 @@ func (n Noop) Debug(message string) {}
 // Debugf implements Logger.
 func (n Noop) Debugf(format string, args ...interface{}) {}
 
+// DebugContext implements Logger.
+func (n Noop) DebugContext(ctx context.Context, message string) {}
+
+// DebugfContext implements Logger.
+func (n Noop) DebugfContext(ctx context.Context, format string, args ...interface{}) {}
+
+// Enabled implements Logger.
+func (n Noop) Enabled(level Level) bool {
+	return false
+}
+
+// Debugw implements Logger.
+func (n Noop) Debugw(message string, fields ...Field) {}
+
 // Error implements Logger.
 func (n Noop) Error(message string) {}
 
 // Errorf implements Logger.
 func (n Noop) Errorf(format string, args ...interface{}) {}
 
+// ErrorContext implements Logger.
+func (n Noop) ErrorContext(ctx context.Context, message string) {}
+
+// ErrorfContext implements Logger.
+func (n Noop) ErrorfContext(ctx context.Context, format string, args ...interface{}) {}
+
+// Errorw implements Logger.
+func (n Noop) Errorw(message string, fields ...Field) {}
+
 // Fatal implements Logger.
-func (n Noop) Fatal(message string) {}
+func (n Noop) Fatal(message string) {
+	n.exit()
+}
 
 // Fatalf implements Logger.
-func (n Noop) Fatalf(format string, args ...interface{}) {}
+func (n Noop) Fatalf(format string, args ...interface{}) {
+	n.exit()
+}
 
 // Fields implements Logger.
 func (n Noop) GetFields() Fields {
@@ -43,28 +76,93 @@ func (n Noop) Info(message string) {}
 // Infof implements Logger.
 func (n Noop) Infof(format string, args ...interface{}) {}
 
+// InfoContext implements Logger.
+func (n Noop) InfoContext(ctx context.Context, message string) {}
+
+// InfofContext implements Logger.
+func (n Noop) InfofContext(ctx context.Context, format string, args ...interface{}) {}
+
+// Infow implements Logger.
+func (n Noop) Infow(message string, fields ...Field) {}
+
+// Level implements Logger.
+func (n Noop) Level() Level {
+	return LevelInfo
+}
+
+// Log implements Logger.
+func (n Noop) Log(level Level, message string, fields ...Field) {
+	switch level {
+	case LevelPanic:
+		panic(MaskMessage(message))
+	case LevelFatal:
+		n.exit()
+	}
+}
+
+func (n Noop) exit() {
+	if n.OnFatal != nil {
+		n.OnFatal()
+		return
+	}
+	Exit(1)
+}
+
 // Output implements Logger.
 func (n Noop) Output() io.Writer {
 	return io.Discard
 }
 
-// Panic implements Logger.
-func (n Noop) Panic(message string) {}
+// Sync implements Logger.
+func (n Noop) Sync() error {
+	return nil
+}
+
+// Close implements Logger.
+func (n Noop) Close() error {
+	return nil
+}
+
+// Panic implements Logger. Nothing is written, but it panics with message
+// like every logger.
+func (n Noop) Panic(message string) {
+	panic(MaskMessage(message))
+}
+
+// Panicf implements Logger, panicking with the formatted message.
+func (n Noop) Panicf(format string, args ...interface{}) {
+	panic(MaskMessage(fmt.Sprintf(format, args...)))
+}
 
-// Panicf implements Logger.
-func (n Noop) Panicf(format string, args ...interface{}) {}
+// SetLevel implements Logger.
+func (n Noop) SetLevel(level Level) {}
 
 // ToContext implements Logger.
 func (n Noop) ToContext(ctx context.Context) context.Context {
 	return ctx
 }
 
+// Trace implements Logger.
+func (n Noop) Trace(message string) {}
+
+// Tracef implements Logger.
+func (n Noop) Tracef(format string, args ...interface{}) {}
+
 // Warn implements Logger.
 func (n Noop) Warn(message string) {}
 
 // Warnf implements Logger.
 func (n Noop) Warnf(format string, args ...interface{}) {}
 
+// WarnContext implements Logger.
+func (n Noop) WarnContext(ctx context.Context, message string) {}
+
+// WarnfContext implements Logger.
+func (n Noop) WarnfContext(ctx context.Context, format string, args ...interface{}) {}
+
+// Warnw implements Logger.
+func (n Noop) Warnw(message string, fields ...Field) {}
+
 // WithField implements Logger.
 func (n Noop) WithField(field Field) Logger {
 	return n
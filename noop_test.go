@@ -0,0 +1,25 @@
+package logr_test
+
+import (
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+)
+
+func TestNoopPanic(t *testing.T) {
+	var l logr.Logger = logr.Noop{}
+	for want, log := range map[string]func(){
+		"boom":   func() { l.Panic("boom") },
+		"boom 1": func() { l.Panicf("boom %d", 1) },
+		"boom 2": func() { l.Log(logr.LevelPanic, "boom 2") },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != want {
+					t.Errorf("panicked with %#v, want %q", r, want)
+				}
+			}()
+			log()
+		}()
+	}
+}
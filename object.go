@@ -0,0 +1,43 @@
+package logr
+
+import "time"
+
+type (
+	// ObjectMarshaler is implemented by types that know how to log themselves
+	// as a structured object, without reflection. Log them with Object.
+	ObjectMarshaler interface {
+		MarshalLogObject(enc ObjectEncoder) error
+	}
+
+	// ObjectEncoder receives the fields of an ObjectMarshaler. Each adapter
+	// translates the calls into its backend's native object encoding.
+	ObjectEncoder interface {
+		AddString(key, value string)
+		AddBool(key string, value bool)
+		AddInt(key string, value int)
+		AddInt64(key string, value int64)
+		AddUint64(key string, value uint64)
+		AddFloat64(key string, value float64)
+		AddTime(key string, value time.Time)
+		AddDuration(key string, value time.Duration)
+		AddStrings(key string, value []string)
+		AddObject(key string, value ObjectMarshaler) error
+		// AddAny adds value with the backend's reflection based encoding.
+		AddAny(key string, value any)
+	}
+
+	// ObjectMarshalerFunc adapts a function to ObjectMarshaler.
+	ObjectMarshalerFunc func(enc ObjectEncoder) error
+)
+
+// MarshalLogObject implements ObjectMarshaler.
+func (f ObjectMarshalerFunc) MarshalLogObject(enc ObjectEncoder) error {
+	return f(enc)
+}
+
+// Object logs value as a nested object. If MarshalLogObject fails, the fields
+// added so far are kept and the error message is added under ErrorKey inside
+// the object.
+func Object(key string, value ObjectMarshaler) Field {
+	return Field{Key: key, Value: value, Type: ObjectType}
+}
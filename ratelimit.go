@@ -0,0 +1,322 @@
+package logr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RepeatedKey is the key of the field WithRateLimit adds to a record with
+// how many identical ones were suppressed before it.
+const RepeatedKey = "repeated"
+
+// rateLimitSweep is the number of windows above which a limiter drops the
+// expired ones, reporting what they suppressed.
+const rateLimitSweep = 1024
+
+// Decorator wraps a Logger to change what it logs, so it works the same over
+// every adapter.
+type Decorator func(Logger) Logger
+
+var (
+	// rateLimiters holds the *rateLimiter of each rateLimitKey given to
+	// WithRateLimit.
+	rateLimiters sync.Map
+
+	// rateLimitNow é trocado pelos testes
+	rateLimitNow = time.Now
+
+	// rateLimitMaxWindows bounds the windows of a limiter; beyond it the one
+	// that expires first is reported and dropped.
+	rateLimitMaxWindows = 16384 // os testes o reduzem
+)
+
+// WithRateLimit returns a Decorator that emits an Error record at most once
+// per every. The identical records, with the same message and fields, logged
+// during the window are suppressed, and the first one emitted after it
+// carries their count in a RepeatedKey field; when none comes, the last one
+// suppressed is logged with the count once the window expires. Close logs the
+// counts still pending. Other levels are left alone.
+//
+// Loggers decorated with the same key and every share the windows, so a key
+// should name something fixed, such as a downstream. The caller logged by the
+// adapters, when enabled, is the decorator's.
+//
+// The decorated logger forwards SinkLevels and AsyncDropped to the one it
+// wraps, so admin.SinkLeveler and DropCounter see through it.
+func WithRateLimit(key string, every time.Duration) Decorator {
+	v, _ := rateLimiters.LoadOrStore(rateLimitKey{key: key, every: every}, &rateLimiter{
+		every:   every,
+		windows: map[string]*rateWindow{},
+	})
+	limiter := v.(*rateLimiter)
+	return func(l Logger) Logger {
+		return &rateLimited{Logger: l, limiter: limiter}
+	}
+}
+
+type (
+	rateLimitKey struct {
+		key   string
+		every time.Duration
+	}
+
+	rateLimiter struct {
+		every   time.Duration
+		mu      sync.Mutex
+		windows map[string]*rateWindow
+	}
+
+	rateWindow struct {
+		until    time.Time
+		repeated int
+		// o último registro suprimido, para o Close reportar a contagem
+		logger  Logger
+		message string
+		fields  Fields
+	}
+
+	rateLimited struct {
+		Logger
+		limiter *rateLimiter
+	}
+)
+
+// allow reports whether the record identified by id is emitted and, when it
+// is, how many were suppressed since the previous one. expired holds the
+// windows dropped to make room, whose suppressed records the caller reports.
+func (r *rateLimiter) allow(id string, l Logger, message string, fields Fields) (repeated int, ok bool, expired []rateWindow) {
+	now := rateLimitNow()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, found := r.windows[id]
+	if found && now.Before(w.until) {
+		if w.repeated == 0 {
+			r.schedule(id, w)
+		}
+		w.repeated++
+		w.logger, w.message, w.fields = l, message, fields
+		return 0, false, nil
+	}
+	if !found {
+		expired = r.sweep(now)
+		w = &rateWindow{}
+		r.windows[id] = w
+	}
+	repeated = w.repeated
+	*w = rateWindow{until: now.Add(r.every)}
+	return repeated, true, expired
+}
+
+// sweep drops the expired windows once there are many of them and, at
+// rateLimitMaxWindows, the one that expires first, returning those with
+// suppressed records to report.
+func (r *rateLimiter) sweep(now time.Time) []rateWindow {
+	var pending []rateWindow
+	if len(r.windows) >= rateLimitSweep {
+		for id, w := range r.windows {
+			if !now.Before(w.until) {
+				if w.repeated > 0 {
+					pending = append(pending, *w)
+				}
+				delete(r.windows, id)
+			}
+		}
+	}
+	if len(r.windows) >= rateLimitMaxWindows {
+		var first string
+		var found bool
+		for id, w := range r.windows {
+			if !found || w.until.Before(r.windows[first].until) {
+				first, found = id, true
+			}
+		}
+		if w := r.windows[first]; w.repeated > 0 {
+			pending = append(pending, *w)
+		}
+		delete(r.windows, first)
+	}
+	return pending
+}
+
+// schedule reports the records suppressed in w when it expires, in case no
+// identical record comes after it to carry the count.
+func (r *rateLimiter) schedule(id string, w *rateWindow) {
+	until := w.until
+	time.AfterFunc(until.Sub(rateLimitNow()), func() {
+		r.mu.Lock()
+		// a janela pode ter sido reaberta, reportada ou descartada
+		if r.windows[id] != w || !w.until.Equal(until) || w.repeated == 0 || rateLimitNow().Before(until) {
+			r.mu.Unlock()
+			return
+		}
+		pending := *w
+		delete(r.windows, id)
+		r.mu.Unlock()
+
+		pending.report()
+	})
+}
+
+// flush logs the suppressed counts not yet reported and forgets the windows.
+func (r *rateLimiter) flush() {
+	r.mu.Lock()
+	var pending []rateWindow
+	for _, w := range r.windows {
+		if w.repeated > 0 {
+			pending = append(pending, *w)
+		}
+	}
+	clear(r.windows)
+	r.mu.Unlock()
+
+	for _, w := range pending {
+		w.report()
+	}
+}
+
+// report logs the last record suppressed in w with how many there were.
+func (w rateWindow) report() {
+	w.logger.Errorw(w.message, withRepeated(w.fields, w.repeated)...)
+}
+
+// rateLimitID identifies a record by its message and the fields of the
+// logger and of the call.
+func rateLimitID(message string, bound, fields Fields) string {
+	var b strings.Builder
+	b.WriteString(message)
+	for _, fs := range []Fields{bound, fields} {
+		for _, f := range fs {
+			b.WriteByte(0)
+			b.WriteString(f.Key)
+			b.WriteByte('=')
+			// campo preguiçoso só é resolvido quando o registro sai
+			if !f.IsLazy() {
+				fmt.Fprint(&b, f.Value)
+			}
+		}
+	}
+	return b.String()
+}
+
+func withRepeated(fields Fields, repeated int) Fields {
+	// não escreve no array de quem chamou
+	return append(fields[:len(fields):len(fields)], Int(RepeatedKey, repeated))
+}
+
+// log emits with emit the Error record logged through scoped, unless an
+// identical one was emitted in the window. The fields of scoped, those of the
+// context in the *Context methods, tell the records apart.
+func (r *rateLimited) log(scoped Logger, message string, fields Fields, emit func(string, ...Field)) {
+	if !scoped.Enabled(LevelError) {
+		return
+	}
+	repeated, ok, expired := r.limiter.allow(rateLimitID(message, scoped.GetFields(), fields), scoped, message, fields)
+	for _, w := range expired {
+		w.report()
+	}
+	if !ok {
+		return
+	}
+	if repeated > 0 {
+		fields = withRepeated(fields, repeated)
+	}
+	emit(message, fields...)
+}
+
+// errorContext emits through ErrorContext, so ctx still reaches the backend.
+func (r *rateLimited) errorContext(ctx context.Context) func(string, ...Field) {
+	return func(message string, fields ...Field) {
+		l := r.Logger
+		if len(fields) > 0 {
+			l = l.WithFields(fields...)
+		}
+		l.ErrorContext(ctx, message)
+	}
+}
+
+// Error implements Logger.
+func (r *rateLimited) Error(message string) {
+	r.log(r.Logger, message, nil, r.Logger.Errorw)
+}
+
+// Errorf implements Logger.
+func (r *rateLimited) Errorf(format string, args ...interface{}) {
+	if r.Enabled(LevelError) {
+		r.log(r.Logger, fmt.Sprintf(format, args...), nil, r.Logger.Errorw)
+	}
+}
+
+// ErrorContext implements Logger.
+func (r *rateLimited) ErrorContext(ctx context.Context, message string) {
+	if r.Enabled(LevelError) {
+		r.log(r.Logger.FromContext(ctx), message, nil, r.errorContext(ctx))
+	}
+}
+
+// ErrorfContext implements Logger.
+func (r *rateLimited) ErrorfContext(ctx context.Context, format string, args ...interface{}) {
+	if r.Enabled(LevelError) {
+		r.log(r.Logger.FromContext(ctx), fmt.Sprintf(format, args...), nil, r.errorContext(ctx))
+	}
+}
+
+// Errorw implements Logger.
+func (r *rateLimited) Errorw(message string, fields ...Field) {
+	r.log(r.Logger, message, fields, r.Logger.Errorw)
+}
+
+// Log implements Logger.
+func (r *rateLimited) Log(level Level, message string, fields ...Field) {
+	if level == LevelError {
+		r.log(r.Logger, message, fields, r.Logger.Errorw)
+		return
+	}
+	r.Logger.Log(level, message, fields...)
+}
+
+// WithFields implements Logger.
+func (r *rateLimited) WithFields(fields ...Field) Logger {
+	return &rateLimited{Logger: r.Logger.WithFields(fields...), limiter: r.limiter}
+}
+
+// WithField implements Logger.
+func (r *rateLimited) WithField(field Field) Logger {
+	return r.WithFields(field)
+}
+
+// FromContext implements Logger.
+func (r *rateLimited) FromContext(ctx context.Context) Logger {
+	return &rateLimited{Logger: r.Logger.FromContext(ctx), limiter: r.limiter}
+}
+
+// SinkLevels returns the levels of the sinks of the decorated logger, or nil
+// when it keeps a single level.
+func (r *rateLimited) SinkLevels() map[string]*AtomicLevel {
+	if sl, ok := r.Logger.(interface {
+		SinkLevels() map[string]*AtomicLevel
+	}); ok {
+		return sl.SinkLevels()
+	}
+	return nil
+}
+
+// AsyncDropped implements DropCounter for the decorated logger. It is nil
+// when that logger is not a DropCounter.
+func (r *rateLimited) AsyncDropped() map[string]uint64 {
+	if dc, ok := r.Logger.(DropCounter); ok {
+		return dc.AsyncDropped()
+	}
+	return nil
+}
+
+// Close implements Logger. It logs the suppressed counts still pending
+// before closing the decorated logger.
+func (r *rateLimited) Close() error {
+	r.limiter.flush()
+	return r.Logger.Close()
+}
@@ -0,0 +1,151 @@
+package logr_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/BrunoTulio/logr"
+	"github.com/BrunoTulio/logr/logrtest"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	now := time.Now()
+	defer logr.SetRateLimitNow(func() time.Time { return now })()
+
+	rec := logrtest.NewRecorder()
+	l := logr.WithRateLimit(t.Name(), time.Minute)(rec).WithField(logr.String("downstream", "payments"))
+	err := logr.Err(errors.New("connection refused"))
+
+	for range 5 {
+		l.Errorw("call failed", err)
+	}
+	l.Errorw("call failed", logr.Err(errors.New("timeout")))
+	l.Warn("retrying")
+	l.Warn("retrying")
+
+	now = now.Add(time.Minute)
+	l.Errorw("call failed", err)
+	l.Errorw("call failed", err)
+	l.Errorw("call failed", err)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	for _, e := range rec.FilterByMessage("call failed") {
+		if !e.Has(err) {
+			continue
+		}
+		repeated := 0
+		for _, f := range e.Fields {
+			if f.Key == logr.RepeatedKey {
+				repeated = f.Value.(int)
+			}
+		}
+		got = append(got, repeated)
+	}
+	// um por janela com os suprimidos da anterior, e o Close reporta o resto
+	want := []int{0, 4, 2}
+	if !slices.Equal(got, want) {
+		t.Errorf("repeated per record = %v, want %v", got, want)
+	}
+	if n := len(rec.FilterByMessage("call failed")) - len(got); n != 1 {
+		t.Errorf("kept %d records with other fields, want 1", n)
+	}
+	if n := len(rec.FilterByMessage("retrying")); n != 2 {
+		t.Errorf("kept %d warnings, want 2: only errors are limited", n)
+	}
+}
+
+func TestWithRateLimitContext(t *testing.T) {
+	now := time.Now()
+	defer logr.SetRateLimitNow(func() time.Time { return now })()
+
+	rec := logrtest.NewRecorder()
+	l := logr.WithRateLimit(t.Name(), time.Minute)(rec)
+	defer l.Close()
+	first := rec.WithField(logr.String("request_id", "r1")).ToContext(context.Background())
+	second := rec.WithField(logr.String("request_id", "r2")).ToContext(context.Background())
+
+	// os campos do contexto separam as janelas
+	for range 3 {
+		l.ErrorContext(first, "call failed")
+		l.ErrorfContext(second, "call %s", "failed")
+	}
+
+	for _, id := range []string{"r1", "r2"} {
+		if n := len(rec.FilterByField("request_id", id)); n != 1 {
+			t.Errorf("kept %d records of request %s, want 1 with its context fields", n, id)
+		}
+	}
+}
+
+func TestWithRateLimitEvery(t *testing.T) {
+	now := time.Now()
+	defer logr.SetRateLimitNow(func() time.Time { return now })()
+
+	rec := logrtest.NewRecorder()
+	minute := logr.WithRateLimit(t.Name(), time.Minute)(rec)
+	hour := logr.WithRateLimit(t.Name(), time.Hour)(rec)
+	defer minute.Close()
+	defer hour.Close()
+
+	minute.Error("call failed")
+	hour.Error("call failed")
+	now = now.Add(time.Minute)
+	minute.Error("call failed")
+	hour.Error("call failed")
+
+	// cada intervalo tem as próprias janelas
+	if n := len(rec.FilterByMessage("call failed")); n != 3 {
+		t.Errorf("kept %d records, want 3: the hourly window must not be shared", n)
+	}
+}
+
+func TestWithRateLimitExpiry(t *testing.T) {
+	rec := logrtest.NewRecorder()
+	l := logr.WithRateLimit(t.Name(), 20*time.Millisecond)(rec)
+	defer l.Close()
+
+	// o serviço se recupera: nenhum erro idêntico vem depois da janela
+	for range 3 {
+		l.Error("call failed")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(rec.FilterByField(logr.RepeatedKey, 2)) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("no record with %s=2 after the window expired: %v", logr.RepeatedKey, rec.Entries())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWithRateLimitMaxWindows(t *testing.T) {
+	now := time.Now()
+	defer logr.SetRateLimitNow(func() time.Time { return now })()
+	defer logr.SetRateLimitMaxWindows(2)()
+
+	rec := logrtest.NewRecorder()
+	l := logr.WithRateLimit(t.Name(), time.Minute)(rec)
+	defer l.Close()
+
+	l.Error("a")
+	l.Error("a")
+	now = now.Add(time.Second)
+	l.Error("b")
+	// o terceiro erro distinto descarta a janela de "a", que expira primeiro
+	l.Error("c")
+	if got := rec.FilterByField(logr.RepeatedKey, 1); len(got) != 1 || got[0].Message != "a" {
+		t.Fatalf("records with %s=1 = %v, want the one of the evicted window", logr.RepeatedKey, got)
+	}
+
+	// sem a janela, "a" volta a sair
+	l.Error("a")
+	if n := len(rec.FilterByMessage("a")); n != 3 {
+		t.Errorf("got %d records of a, want 3", n)
+	}
+}
@@ -0,0 +1,294 @@
+package logr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RedactedValue is what a redacted field renders as under RedactMask.
+const RedactedValue = "[REDACTED]"
+
+// RedactMode is how a redacted field is rendered.
+type RedactMode int
+
+const (
+	// RedactMask replaces the value with RedactedValue.
+	RedactMask RedactMode = iota
+	// RedactHash replaces the value with "sha256:" and the first 16 hex
+	// digits of its SHA-256, so equal secrets can still be correlated.
+	RedactHash
+)
+
+var redactModeNames = []string{"MASK", "HASH"}
+
+// String returns the name of m, as in "HASH".
+func (m RedactMode) String() string {
+	if m >= 0 && int(m) < len(redactModeNames) {
+		return redactModeNames[m]
+	}
+	return fmt.Sprintf("RedactMode(%d)", int(m))
+}
+
+type redaction struct {
+	mode     RedactMode
+	patterns []string
+}
+
+// redactionPolicy is nil until SetRedaction, when only Secret fields are
+// redacted, with RedactMask.
+var redactionPolicy atomic.Pointer[redaction]
+
+// SetRedaction makes every adapter redact, with mode, the fields whose key
+// matches one of patterns and every Secret field, at any depth of Group and
+// Object fields. A pattern matches the whole key, ignoring case, and "*"
+// matches any run of characters, as in "password", "*token*" or
+// "authorization".
+//
+// Only keys are matched. The value of an Any field, or of a Lazy one once
+// rendered, is written as it is, so a map or struct holding a secret under a
+// matching key is not redacted; log such values with Object or Group, whose
+// keys are matched, or as Secret.
+//
+// It is meant to be called once, in main, before the loggers are created:
+// the fields given to WithFields are rendered when the derived logger is.
+func SetRedaction(mode RedactMode, patterns ...string) {
+	lower := make([]string, len(patterns))
+	for i, p := range patterns {
+		lower[i] = strings.ToLower(p)
+	}
+	redactionPolicy.Store(&redaction{mode: mode, patterns: lower})
+}
+
+// GetRedaction returns the mode and the patterns, in lower case, given to
+// SetRedaction, so they can be restored later; before it is called they are
+// RedactMask and none.
+func GetRedaction() (RedactMode, []string) {
+	r := redactionPolicy.Load()
+	if r == nil {
+		return RedactMask, nil
+	}
+	return r.mode, slices.Clone(r.patterns)
+}
+
+// Secret logs value redacted, as RedactedValue or, under RedactHash, its
+// hash, whatever the key and the patterns given to SetRedaction.
+func Secret(key string, value any) Field {
+	return Field{Key: key, Value: value, Type: SecretType}
+}
+
+// RedactFields returns fields with Redact applied to each one. fields itself
+// is returned when none of them changes.
+func RedactFields(fields Fields) Fields {
+	redacted, _ := redactFields(fields)
+	return redacted
+}
+
+// Redact returns f as the adapters must render it: a String with the
+// redacted value when f is a Secret or its key matches a pattern, its values
+// masked by the Masker given to SetMasker, and Group and Object fields with
+// their nested fields redacted.
+func Redact(f Field) Field {
+	redacted, _ := redact(f)
+	return redacted
+}
+
+func redactFields(fields Fields) (Fields, bool) {
+	var redacted Fields
+	for i, f := range fields {
+		rf, changed := redact(f)
+		if changed && redacted == nil {
+			// só copia quando algum campo muda
+			redacted = slices.Clone(fields)
+		}
+		if redacted != nil {
+			redacted[i] = rf
+		}
+	}
+	if redacted == nil {
+		return fields, false
+	}
+	return redacted, true
+}
+
+func redact(f Field) (Field, bool) {
+	r := redactionPolicy.Load()
+	if f.Type == SecretType || (r != nil && r.matches(f.Key)) {
+		return String(f.Key, r.render(f.Value)), true
+	}
+
+	if m := maskerPolicy.Load(); m != nil {
+		if masked, changed := maskField(m, f); changed {
+			return masked, true
+		}
+	}
+	if groupFields, ok := f.Value.([]Field); ok && f.Type == GroupType {
+		if redacted, changed := redactFields(groupFields); changed {
+			return Group(f.Key, redacted...), true
+		}
+	}
+	// dentro de um objeto só as chaves podem casar
+	if v, ok := f.Value.(ObjectMarshaler); ok && f.Type == ObjectType && r != nil && len(r.patterns) > 0 {
+		return Object(f.Key, redactedObject{object: v, redaction: r}), true
+	}
+	return f, false
+}
+
+func (r *redaction) matches(key string) bool {
+	for _, p := range r.patterns {
+		if matchPattern(p, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// render returns the redacted value; r may be nil.
+func (r *redaction) render(value any) string {
+	if r == nil || r.mode != RedactHash {
+		return RedactedValue
+	}
+
+	var sum [sha256.Size]byte
+	switch v := value.(type) {
+	case string:
+		sum = sha256.Sum256([]byte(v))
+	case []byte:
+		sum = sha256.Sum256(v)
+	default:
+		sum = sha256.Sum256([]byte(fmt.Sprint(v)))
+	}
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// matchPattern reports whether key matches pattern, already in lower case,
+// ignoring the case of ASCII letters in key.
+func matchPattern(pattern, key string) bool {
+	// backtracking clássico: volta para o último "*" quando um byte não casa
+	p, k := 0, 0
+	star, mark := -1, 0
+	for k < len(key) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, k
+			p++
+		case p < len(pattern) && pattern[p] == lowerASCII(key[k]):
+			p++
+			k++
+		case star >= 0:
+			p = star + 1
+			mark++
+			k = mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// redactedObject redacts the keys an ObjectMarshaler adds that match the
+// patterns.
+type redactedObject struct {
+	object    ObjectMarshaler
+	redaction *redaction
+}
+
+// MarshalLogObject implements ObjectMarshaler.
+func (o redactedObject) MarshalLogObject(enc ObjectEncoder) error {
+	return o.object.MarshalLogObject(redactingEncoder{enc: enc, redaction: o.redaction})
+}
+
+type redactingEncoder struct {
+	enc       ObjectEncoder
+	redaction *redaction
+}
+
+// redact adds the redacted value under key and reports whether it did.
+func (e redactingEncoder) redact(key string, value any) bool {
+	if !e.redaction.matches(key) {
+		return false
+	}
+	e.enc.AddString(key, e.redaction.render(value))
+	return true
+}
+
+func (e redactingEncoder) AddString(key, value string) {
+	if !e.redact(key, value) {
+		e.enc.AddString(key, value)
+	}
+}
+
+func (e redactingEncoder) AddBool(key string, value bool) {
+	if !e.redact(key, value) {
+		e.enc.AddBool(key, value)
+	}
+}
+
+func (e redactingEncoder) AddInt(key string, value int) {
+	if !e.redact(key, value) {
+		e.enc.AddInt(key, value)
+	}
+}
+
+func (e redactingEncoder) AddInt64(key string, value int64) {
+	if !e.redact(key, value) {
+		e.enc.AddInt64(key, value)
+	}
+}
+
+func (e redactingEncoder) AddUint64(key string, value uint64) {
+	if !e.redact(key, value) {
+		e.enc.AddUint64(key, value)
+	}
+}
+
+func (e redactingEncoder) AddFloat64(key string, value float64) {
+	if !e.redact(key, value) {
+		e.enc.AddFloat64(key, value)
+	}
+}
+
+func (e redactingEncoder) AddTime(key string, value time.Time) {
+	if !e.redact(key, value) {
+		e.enc.AddTime(key, value)
+	}
+}
+
+func (e redactingEncoder) AddDuration(key string, value time.Duration) {
+	if !e.redact(key, value) {
+		e.enc.AddDuration(key, value)
+	}
+}
+
+func (e redactingEncoder) AddStrings(key string, value []string) {
+	if !e.redact(key, value) {
+		e.enc.AddStrings(key, value)
+	}
+}
+
+func (e redactingEncoder) AddObject(key string, value ObjectMarshaler) error {
+	if e.redact(key, value) {
+		return nil
+	}
+	return e.enc.AddObject(key, redactedObject{object: value, redaction: e.redaction})
+}
+
+func (e redactingEncoder) AddAny(key string, value any) {
+	if !e.redact(key, value) {
+		e.enc.AddAny(key, value)
+	}
+}
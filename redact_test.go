@@ -0,0 +1,68 @@
+package logr_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/BrunoTulio/logr"
+)
+
+func TestRedact(t *testing.T) {
+	logr.SetRedaction(logr.RedactMask, "password", "*token*", "authorization")
+	t.Cleanup(func() { logr.SetRedaction(logr.RedactMask) })
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"password", true},
+		{"PASSWORD", true},
+		{"password_hash", false},
+		{"token", true},
+		{"accessToken", true},
+		{"x-token-id", true},
+		{"Authorization", true},
+		{"author", false},
+		{"user", false},
+	}
+	for _, tt := range tests {
+		f := logr.Redact(logr.String(tt.key, "v"))
+		if got := f.Value == logr.RedactedValue; got != tt.want {
+			t.Errorf("Redact(%q) = %v, want redacted %v", tt.key, f.Value, tt.want)
+		}
+	}
+}
+
+func TestRedactHash(t *testing.T) {
+	logr.SetRedaction(logr.RedactHash)
+	t.Cleanup(func() { logr.SetRedaction(logr.RedactMask) })
+
+	a := logr.Redact(logr.Secret("card", "4111111111111111"))
+	b := logr.Redact(logr.Secret("other", "4111111111111111"))
+	v, _ := a.Value.(string)
+	if a.Type != logr.StringType || !strings.HasPrefix(v, "sha256:") || strings.Contains(v, "4111") {
+		t.Fatalf("Redact(Secret) = %+v, want a sha256 hash", a)
+	}
+	if a.Value != b.Value {
+		t.Errorf("equal secrets hashed to %v and %v", a.Value, b.Value)
+	}
+}
+
+func TestRedactFieldsUnchanged(t *testing.T) {
+	fields := logr.Fields{logr.String("user", "ana"), logr.Group("http", logr.Int("status", 200))}
+	if got := logr.RedactFields(fields); &got[0] != &fields[0] {
+		t.Error("RedactFields copied fields with nothing to redact")
+	}
+}
+
+func TestGetRedaction(t *testing.T) {
+	mode, patterns := logr.GetRedaction()
+	t.Cleanup(func() { logr.SetRedaction(mode, patterns...) })
+
+	logr.SetRedaction(logr.RedactHash, "Password", "*token*")
+	mode, patterns = logr.GetRedaction()
+	if mode != logr.RedactHash || !slices.Equal(patterns, []string{"password", "*token*"}) {
+		t.Errorf("GetRedaction() = %s, %q, want HASH and the patterns in lower case", mode, patterns)
+	}
+}
@@ -0,0 +1,127 @@
+package logr
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SamplingMessage is the message of the record the adapters log once per
+// sampling interval with the number of records suppressed in it, under the
+// "suppressed" key.
+const SamplingMessage = "sampling suppressed records"
+
+// samplerSlots bounds the memory of a Sampler: records whose level and
+// message hash to the same slot share a counter, as in zap.
+const samplerSlots = 4096
+
+// Sampler keeps, in each interval, the first initial records with the same
+// level and message and then one of every thereafter, dropping the others; a
+// zero thereafter drops them all. It also counts what is suppressed and
+// reports it with Report.
+type Sampler struct {
+	initial    uint64
+	thereafter uint64
+	interval   time.Duration
+	counters   [samplerSlots]samplerCounter
+	suppressed atomic.Uint64
+
+	reporting atomic.Bool
+	stopOnce  sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+type samplerCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// NewSampler returns a Sampler for the given limits. Report must be called
+// for the suppressed records to be reported.
+func NewSampler(initial, thereafter int, interval time.Duration) *Sampler {
+	return &Sampler{
+		initial:    uint64(max(initial, 0)),
+		thereafter: uint64(max(thereafter, 0)),
+		interval:   interval,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Sample reports whether the record with level and message is kept. Panic
+// and Fatal records are always kept.
+func (s *Sampler) Sample(level Level, message string) bool {
+	if level >= LevelPanic {
+		return true
+	}
+	n := s.counters[samplerSlot(level, message)].inc(time.Now().UnixNano(), s.interval)
+	if n <= s.initial || (s.thereafter > 0 && (n-s.initial)%s.thereafter == 0) {
+		return true
+	}
+	s.suppressed.Add(1)
+	return false
+}
+
+// Report calls fn once per interval with the records suppressed in it, when
+// there are any, until Stop. Only the first call has effect.
+func (s *Sampler) Report(fn func(suppressed uint64)) {
+	if !s.reporting.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				// o que ficou do último intervalo ainda é reportado
+				s.report(fn)
+				return
+			}
+			s.report(fn)
+		}
+	}()
+}
+
+// Stop ends Report, reporting what was suppressed since its last call.
+func (s *Sampler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		if s.reporting.Load() {
+			<-s.done
+		}
+	})
+}
+
+func (s *Sampler) report(fn func(uint64)) {
+	if n := s.suppressed.Swap(0); n > 0 {
+		fn(n)
+	}
+}
+
+func (c *samplerCounter) inc(now int64, interval time.Duration) uint64 {
+	if c.resetAt.Load() > now {
+		return c.count.Add(1)
+	}
+	// corrida benigna: dois registros podem reiniciar a janela juntos
+	c.count.Store(1)
+	c.resetAt.Store(now + int64(interval))
+	return 1
+}
+
+// samplerSlot hashes level and message with FNV-1a.
+func samplerSlot(level Level, message string) int {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+	h := uint64(offset)
+	h = (h ^ uint64(byte(level))) * prime
+	for i := 0; i < len(message); i++ {
+		h = (h ^ uint64(message[i])) * prime
+	}
+	return int(h % samplerSlots)
+}
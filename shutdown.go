@@ -0,0 +1,77 @@
+package logr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+)
+
+var shutdownHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// RegisterShutdownHook adds fn to the hooks run by Exit, which is what Fatal
+// calls by default. Hooks run once, in the reverse order they were
+// registered, so a hook can rely on everything registered before it.
+func RegisterShutdownHook(fn func()) {
+	shutdownHooks.mu.Lock()
+	defer shutdownHooks.mu.Unlock()
+	shutdownHooks.hooks = append(shutdownHooks.hooks, fn)
+}
+
+// RunShutdownHooks runs and removes the registered shutdown hooks.
+func RunShutdownHooks() {
+	shutdownHooks.mu.Lock()
+	hooks := shutdownHooks.hooks
+	shutdownHooks.hooks = nil
+	shutdownHooks.mu.Unlock()
+
+	// um hook pode registrar outro; roda sem segurar o lock
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
+
+// Exit runs the shutdown hooks and ends the process with code. It is the
+// default OnFatal of every adapter and of Noop.
+func Exit(code int) {
+	RunShutdownHooks()
+	os.Exit(code)
+}
+
+// Shutdown is meant for the end of main: it runs the shutdown hooks and
+// closes the logger given to Set, flushing what its sinks still buffer. When
+// ctx is done first, Shutdown returns ctx.Err() and leaves them running.
+func Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		RunShutdownHooks()
+		done <- l.Close()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SyncWriter flushes w when it buffers writes, that is, when it has a
+// Sync() error method like *os.File. Other writers are left alone, and so are
+// files that cannot be synced, such as a terminal or a pipe.
+func SyncWriter(w io.Writer) error {
+	s, ok := w.(interface{ Sync() error })
+	if !ok {
+		return nil
+	}
+	// o fsync de um terminal ou pipe falha com EINVAL no Linux e ENOTSUP no macOS
+	if err := s.Sync(); err != nil && !errors.Is(err, syscall.EINVAL) && !errors.Is(err, errors.ErrUnsupported) {
+		return err
+	}
+	return nil
+}